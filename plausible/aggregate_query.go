@@ -19,17 +19,31 @@ type AggregateQuery struct {
 
 // Validate tells whether the query is valid or not.
 // If the query is not valid, a string explaining why the query is not valid will be returned.
+// Besides the mandatory fields, the compatibility of the metrics with the filters and the other fields
+// of the query is checked, and all the problems found are included in the string.
 func (aq *AggregateQuery) Validate() (ok bool, invalidReason string) {
+	return validationResult(aq.validationProblems())
+}
+
+// Check validates the query like Validate, but returns a *ValidationError with all the problems found in the query,
+// or nil if the query is valid.
+func (aq *AggregateQuery) Check() error {
+	return validationError("aggregate", aq.validationProblems())
+}
+
+func (aq *AggregateQuery) validationProblems() []ValidationProblem {
+	v := queryValidator{}
 
 	if aq.Period.IsEmpty() {
-		return false, "a period must be specified for an aggregate query"
+		v.addProblem("Period", "a period must be specified for an aggregate query")
 	}
-
 	if aq.Metrics.IsEmpty() {
-		return false, "at least one metric must be specified for an aggregate query"
+		v.addProblem("Metrics", "at least one metric must be specified for an aggregate query")
 	}
+	v.checkFilters(aq.Filters)
+	v.checkMetrics(aq.Metrics, aq.Filters, "")
 
-	return true, ""
+	return v.problems
 }
 
 func (aq *AggregateQuery) toQueryArgs() QueryArgs {
@@ -91,6 +105,90 @@ type AggregateResult struct {
 	// EventsChange represents the events change compared to the previous period.
 	// Only use this field if you included the Events metric in your query and ComparePreviousPeriod was set to true.
 	EventsChange int `json:"events_change"`
+
+	// ViewsPerVisitRaw represents the views per visit result for the query.
+	// Only use this field if you included the ViewsPerVisit metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the ViewsPerVisit function to get this value.
+	ViewsPerVisitRaw *float64 `json:"views_per_visit"`
+	// ViewsPerVisitChange represents the views per visit change compared to the previous period.
+	// Only use this field if you included the ViewsPerVisit metric in your query and ComparePreviousPeriod was set to true.
+	ViewsPerVisitChange float64 `json:"views_per_visit_change"`
+
+	// ConversionRateRaw represents the conversion rate result for the query.
+	// Only use this field if you included the ConversionRate metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the ConversionRate function to get this value.
+	ConversionRateRaw *float64 `json:"conversion_rate"`
+	// ConversionRateChange represents the conversion rate change compared to the previous period.
+	// Only use this field if you included the ConversionRate metric in your query and ComparePreviousPeriod was set to true.
+	ConversionRateChange float64 `json:"conversion_rate_change"`
+
+	// TimeOnPageRaw represents the time on page result for the query.
+	// Only use this field if you included the TimeOnPage metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the TimeOnPage function to get this value.
+	TimeOnPageRaw *float64 `json:"time_on_page"`
+	// TimeOnPageChange represents the time on page change compared to the previous period.
+	// Only use this field if you included the TimeOnPage metric in your query and ComparePreviousPeriod was set to true.
+	TimeOnPageChange float64 `json:"time_on_page_change"`
+
+	// ScrollDepthRaw represents the scroll depth result for the query.
+	// Only use this field if you included the ScrollDepth metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the ScrollDepth function to get this value.
+	ScrollDepthRaw *float64 `json:"scroll_depth"`
+	// ScrollDepthChange represents the scroll depth change compared to the previous period.
+	// Only use this field if you included the ScrollDepth metric in your query and ComparePreviousPeriod was set to true.
+	ScrollDepthChange float64 `json:"scroll_depth_change"`
+
+	// TotalRevenueRaw represents the total revenue result for the query.
+	// Only use this field if you included the TotalRevenue metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the TotalRevenue function to get this value.
+	TotalRevenueRaw *RevenueValue `json:"total_revenue"`
+	// TotalRevenueChange represents the total revenue change compared to the previous period.
+	// Only use this field if you included the TotalRevenue metric in your query and ComparePreviousPeriod was set to true.
+	TotalRevenueChange float64 `json:"total_revenue_change"`
+
+	// AverageRevenueRaw represents the average revenue result for the query.
+	// Only use this field if you included the AverageRevenue metric in your query, and it can be nil.
+	// If you don't care about the nil value, use the AverageRevenue function to get this value.
+	AverageRevenueRaw *RevenueValue `json:"average_revenue"`
+	// AverageRevenueChange represents the average revenue change compared to the previous period.
+	// Only use this field if you included the AverageRevenue metric in your query and ComparePreviousPeriod was set to true.
+	AverageRevenueChange float64 `json:"average_revenue_change"`
+}
+
+// ViewsPerVisit returns the views per visit result for the query.
+// It will return 0 (zero) if the views per visit information is not present.
+func (ar *AggregateResult) ViewsPerVisit() float64 {
+	return floatOrZero(ar.ViewsPerVisitRaw)
+}
+
+// ConversionRate returns the conversion rate result for the query.
+// It will return 0 (zero) if the conversion rate information is not present.
+func (ar *AggregateResult) ConversionRate() float64 {
+	return floatOrZero(ar.ConversionRateRaw)
+}
+
+// TimeOnPage returns the time on page result for the query.
+// It will return 0 (zero) if the time on page information is not present.
+func (ar *AggregateResult) TimeOnPage() float64 {
+	return floatOrZero(ar.TimeOnPageRaw)
+}
+
+// ScrollDepth returns the scroll depth result for the query.
+// It will return 0 (zero) if the scroll depth information is not present.
+func (ar *AggregateResult) ScrollDepth() float64 {
+	return floatOrZero(ar.ScrollDepthRaw)
+}
+
+// TotalRevenue returns the total revenue result for the query.
+// It will return an empty RevenueValue if the total revenue information is not present.
+func (ar *AggregateResult) TotalRevenue() RevenueValue {
+	return revenueOrZero(ar.TotalRevenueRaw)
+}
+
+// AverageRevenue returns the average revenue result for the query.
+// It will return an empty RevenueValue if the average revenue information is not present.
+func (ar *AggregateResult) AverageRevenue() RevenueValue {
+	return revenueOrZero(ar.AverageRevenueRaw)
 }
 
 type rawAggregateResult struct {
@@ -119,6 +217,30 @@ type rawAggregateResult struct {
 			Change int `json:"change"`
 			Value  int `json:"value"`
 		} `json:"visits,omitempty"`
+		ViewsPerVisit struct {
+			Change float64  `json:"change"`
+			Value  *float64 `json:"value"`
+		} `json:"views_per_visit,omitempty"`
+		ConversionRate struct {
+			Change float64  `json:"change"`
+			Value  *float64 `json:"value"`
+		} `json:"conversion_rate,omitempty"`
+		TimeOnPage struct {
+			Change float64  `json:"change"`
+			Value  *float64 `json:"value"`
+		} `json:"time_on_page,omitempty"`
+		ScrollDepth struct {
+			Change float64  `json:"change"`
+			Value  *float64 `json:"value"`
+		} `json:"scroll_depth,omitempty"`
+		TotalRevenue struct {
+			Change float64       `json:"change"`
+			Value  *RevenueValue `json:"value"`
+		} `json:"total_revenue,omitempty"`
+		AverageRevenue struct {
+			Change float64       `json:"change"`
+			Value  *RevenueValue `json:"value"`
+		} `json:"average_revenue,omitempty"`
 	} `json:"results,omitempty"`
 }
 
@@ -147,5 +269,23 @@ func (r *rawAggregateResult) toAggregateResult() AggregateResult {
 	res.Visits = r.Result.Visits.Value
 	res.VisitsChange = r.Result.Visits.Change
 
+	res.ViewsPerVisitRaw = r.Result.ViewsPerVisit.Value
+	res.ViewsPerVisitChange = r.Result.ViewsPerVisit.Change
+
+	res.ConversionRateRaw = r.Result.ConversionRate.Value
+	res.ConversionRateChange = r.Result.ConversionRate.Change
+
+	res.TimeOnPageRaw = r.Result.TimeOnPage.Value
+	res.TimeOnPageChange = r.Result.TimeOnPage.Change
+
+	res.ScrollDepthRaw = r.Result.ScrollDepth.Value
+	res.ScrollDepthChange = r.Result.ScrollDepth.Change
+
+	res.TotalRevenueRaw = r.Result.TotalRevenue.Value
+	res.TotalRevenueChange = r.Result.TotalRevenue.Change
+
+	res.AverageRevenueRaw = r.Result.AverageRevenue.Value
+	res.AverageRevenueChange = r.Result.AverageRevenue.Change
+
 	return res
 }
@@ -1,6 +1,7 @@
 package plausible
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -35,6 +36,15 @@ func TestUnitValidateAggregateQuery(t *testing.T) {
 			},
 			isValid: false,
 		},
+		{
+			name: "invalid aggregate query due to invalid filter value",
+			query: AggregateQuery{
+				Period:  DayPeriod(),
+				Metrics: AllMetrics(),
+				Filters: NewFilter().ByEventPage("/a;b"),
+			},
+			isValid: false,
+		},
 	}
 	for _, test := range tests {
 		valid, _ := test.query.Validate()
@@ -126,6 +136,35 @@ func TestUnitToAggregateResultConversion(t *testing.T) {
 
 }
 
+func TestUnitAggregateResultDecoding(t *testing.T) {
+	response := `{"results":{"views_per_visit":{"value":2.5,"change":10},"time_on_page":{"value":null,"change":null},` +
+		`"average_revenue":{"value":{"value":20.25,"currency":"USD","short":"$20.3","long":"$20.25"},"change":-5}}}`
+
+	var raw rawAggregateResult
+	if err := json.Unmarshal([]byte(response), &raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res := raw.toAggregateResult()
+
+	if res.ViewsPerVisit() != 2.5 || res.ViewsPerVisitChange != 10 {
+		t.Fatalf("expected 2.5 views per visit with a change of 10, got %v and %v", res.ViewsPerVisit(), res.ViewsPerVisitChange)
+	}
+
+	if res.TimeOnPageRaw != nil || res.TimeOnPage() != 0 {
+		t.Fatalf("expected no time on page, got %v", res.TimeOnPageRaw)
+	}
+
+	expectedRevenue := RevenueValue{Value: 20.25, Currency: "USD", Short: "$20.3", Long: "$20.25"}
+	if res.AverageRevenue() != expectedRevenue || res.AverageRevenueChange != -5 {
+		t.Fatalf("expected average revenue %+v with a change of -5, got %+v and %v",
+			expectedRevenue, res.AverageRevenue(), res.AverageRevenueChange)
+	}
+
+	if res.TotalRevenueRaw != nil {
+		t.Fatalf("expected no total revenue, got %+v", *res.TotalRevenueRaw)
+	}
+}
+
 func TestIntegrationAggregateQuery(t *testing.T) {
 	t.Parallel()
 	year, month, day := time.Now().Date()
@@ -0,0 +1,91 @@
+package plausible
+
+import "context"
+
+// defaultBreakdownLimit is the number of entries in each page of a breakdown query when the query has no limit.
+const defaultBreakdownLimit = 100
+
+// BreakdownIterator iterates over the entries of all the pages of a breakdown query, fetching a page at a time.
+// Use Site.BreakdownIter to get an iterator.
+//
+// The iteration stops after a page with fewer entries than the limit of the query, when the maximum number of entries
+// is reached or when a request fails. Check Err after the iteration to know whether it stopped because of an error:
+//
+//	it := mysite.BreakdownIter(query, 0)
+//	for it.Next() {
+//	    entry := it.Entry()
+//	    // use entry
+//	}
+//	if err := it.Err(); err != nil {
+//	    // handle error
+//	}
+//
+// A BreakdownIterator is not safe for concurrent use.
+type BreakdownIterator struct {
+	ctx     context.Context
+	site    *Site
+	query   BreakdownQuery
+	maxRows int
+
+	page     BreakdownResult
+	index    int
+	rows     int
+	lastPage bool
+	current  BreakdownResultEntry
+	err      error
+}
+
+func newBreakdownIterator(ctx context.Context, site *Site, query BreakdownQuery, maxRows int) *BreakdownIterator {
+	if query.Limit == 0 {
+		query.Limit = defaultBreakdownLimit
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+
+	return &BreakdownIterator{ctx: ctx, site: site, query: query, maxRows: maxRows}
+}
+
+// Next advances the iterator to the next entry, fetching the next page when needed.
+// It returns false when there are no more entries or when a request fails.
+func (it *BreakdownIterator) Next() bool {
+	if it.err != nil || (it.maxRows > 0 && it.rows >= it.maxRows) {
+		return false
+	}
+
+	if it.index >= len(it.page) {
+		if it.lastPage || !it.fetchPage() {
+			return false
+		}
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	it.rows++
+	return true
+}
+
+// Entry returns the current entry. It must only be called after a call to Next that returned true.
+func (it *BreakdownIterator) Entry() BreakdownResultEntry {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *BreakdownIterator) Err() error {
+	return it.err
+}
+
+// fetchPage fetches the next page of entries. It returns false if there are no entries to iterate over.
+func (it *BreakdownIterator) fetchPage() bool {
+	page, err := it.site.BreakdownContext(it.ctx, it.query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page, it.index = page, 0
+	it.lastPage = len(page) < it.query.Limit
+	it.query.Page++
+
+	return len(page) > 0
+}
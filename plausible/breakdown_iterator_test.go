@@ -0,0 +1,145 @@
+package plausible
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newBreakdownPagesServer returns a server that paginates a breakdown of the given number of entries.
+// Requests for the page failingPage fail.
+func newBreakdownPagesServer(entries int, failingPage int, requestedPages *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		*requestedPages = append(*requestedPages, page)
+
+		if page == failingPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"internal error"}`))
+			return
+		}
+
+		results := []map[string]interface{}{}
+		for i := (page - 1) * limit; i < page*limit && i < entries; i++ {
+			results = append(results, map[string]interface{}{"page": fmt.Sprintf("/%d", i), "visitors": i})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+}
+
+func TestUnitBreakdownAll(t *testing.T) {
+	tests := []struct {
+		name            string
+		entries         int
+		query           BreakdownQuery
+		maxRows         int
+		expectedEntries int
+		expectedPages   []int
+	}{
+		{
+			name:            "breakdown with a short last page",
+			entries:         250,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days()},
+			expectedEntries: 250,
+			expectedPages:   []int{1, 2, 3},
+		},
+		{
+			name:            "breakdown with a full last page",
+			entries:         20,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days(), Limit: 10},
+			expectedEntries: 20,
+			expectedPages:   []int{1, 2, 3},
+		},
+		{
+			name:            "breakdown with a cap on the number of entries",
+			entries:         250,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days()},
+			maxRows:         120,
+			expectedEntries: 120,
+			expectedPages:   []int{1, 2},
+		},
+		{
+			name:            "breakdown with a cap smaller than the limit",
+			entries:         250,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days()},
+			maxRows:         5,
+			expectedEntries: 5,
+			expectedPages:   []int{1},
+		},
+		{
+			name:            "breakdown starting at a page with a cap smaller than the limit",
+			entries:         25,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days(), Limit: 10, Page: 2},
+			maxRows:         5,
+			expectedEntries: 5,
+			expectedPages:   []int{2},
+		},
+		{
+			name:            "breakdown starting at a page",
+			entries:         25,
+			query:           BreakdownQuery{Property: EventPage, Period: Last7Days(), Limit: 10, Page: 2},
+			expectedEntries: 15,
+			expectedPages:   []int{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		var requestedPages []int
+		server := newBreakdownPagesServer(test.entries, 0, &requestedPages)
+
+		res, err := NewClientWithBaseURL("token", server.URL).Site("example.com").BreakdownAll(test.query, test.maxRows)
+		server.Close()
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if len(res) != test.expectedEntries {
+			t.Fatalf("test '%s' failed: expected %d entries, got %d", test.name, test.expectedEntries, len(res))
+		}
+
+		first := (test.query.Page - 1) * test.query.Limit
+		if test.query.Page == 0 {
+			first = 0
+		}
+		for i, entry := range res {
+			if entry.Page != fmt.Sprintf("/%d", first+i) {
+				t.Fatalf("test '%s' failed: expected entry %d to be for page /%d, got %s", test.name, i, first+i, entry.Page)
+			}
+		}
+
+		if fmt.Sprint(requestedPages) != fmt.Sprint(test.expectedPages) {
+			t.Fatalf("test '%s' failed: expected requests for pages %v, got %v", test.name, test.expectedPages, requestedPages)
+		}
+	}
+}
+
+func TestUnitBreakdownIterError(t *testing.T) {
+	var requestedPages []int
+	server := newBreakdownPagesServer(250, 2, &requestedPages)
+	defer server.Close()
+
+	it := NewClientWithBaseURL("token", server.URL).Site("example.com").
+		BreakdownIter(BreakdownQuery{Property: EventPage, Period: Last7Days()}, 0)
+
+	entries := 0
+	for it.Next() {
+		entries++
+	}
+
+	if entries != 100 {
+		t.Fatalf("expected the entries of the first page before the error, got %d entries", entries)
+	}
+
+	if !errors.Is(it.Err(), ErrServerError) {
+		t.Fatalf("expected a server error, got %v", it.Err())
+	}
+
+	if it.Next() {
+		t.Fatalf("expected the iteration to stop after an error")
+	}
+}
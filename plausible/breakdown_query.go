@@ -1,6 +1,9 @@
 package plausible
 
-import "strconv"
+import (
+	"encoding/json"
+	"strconv"
+)
 
 // BreakdownQuery represents an API query for detailed information about a property over a period of time.
 // In an breakdown query, the Property field and the Period fields are mandatory, all the others are optional.
@@ -27,15 +30,31 @@ type BreakdownQuery struct {
 
 // Validate tells whether the query is valid or not.
 // If the query is not valid, a string explaining why the query is not valid will be returned.
+// Besides the mandatory fields, the compatibility of the metrics with the filters and the other fields
+// of the query is checked, and all the problems found are included in the string.
 func (bq *BreakdownQuery) Validate() (ok bool, invalidReason string) {
+	return validationResult(bq.validationProblems())
+}
+
+// Check validates the query like Validate, but returns a *ValidationError with all the problems found in the query,
+// or nil if the query is valid.
+func (bq *BreakdownQuery) Check() error {
+	return validationError("breakdown", bq.validationProblems())
+}
+
+func (bq *BreakdownQuery) validationProblems() []ValidationProblem {
+	v := queryValidator{}
+
 	if bq.Property.IsEmpty() {
-		return false, "a property must be specified for a breakdown query"
+		v.addProblem("Property", "a property must be specified for a breakdown query")
 	}
 	if bq.Period.IsEmpty() {
-		return false, "a period must be specified for a breakdown query"
+		v.addProblem("Period", "a period must be specified for a breakdown query")
 	}
+	v.checkFilters(bq.Filters)
+	v.checkMetrics(bq.Metrics, bq.Filters, bq.Property)
 
-	return true, ""
+	return v.problems
 }
 
 func (bq *BreakdownQuery) toQueryArgs() QueryArgs {
@@ -106,6 +125,34 @@ type PropertyResult struct {
 	// Country contains a value of the country property.
 	// This value must only be only if the breakdown query was for this property.
 	Country string `json:"country"`
+	// EntryPage contains a value of the entry page property.
+	// This value must only be only if the breakdown query was for this property.
+	EntryPage string `json:"entry_page"`
+	// ExitPage contains a value of the exit page property.
+	// This value must only be only if the breakdown query was for this property.
+	ExitPage string `json:"exit_page"`
+	// Region contains a value of the region property, the ISO 3166-2 code of the region.
+	// This value must only be only if the breakdown query was for this property.
+	Region string `json:"region"`
+	// City contains a value of the city property, the GeoNames ID of the city.
+	// The API sends this value as a number, json.Number keeps it as it was sent.
+	// This value must only be only if the breakdown query was for this property.
+	City json.Number `json:"city"`
+	// UtmContent contains a value of the utm content property.
+	// This value must only be only if the breakdown query was for this property.
+	UtmContent string `json:"utm_content"`
+	// UtmTerm contains a value of the utm term property.
+	// This value must only be only if the breakdown query was for this property.
+	UtmTerm string `json:"utm_term"`
+	// Channel contains a value of the acquisition channel property.
+	// This value must only be only if the breakdown query was for this property.
+	Channel string `json:"channel"`
+	// Goal contains a value of the goal property.
+	// This value must only be only if the breakdown query was for this property.
+	Goal string `json:"goal"`
+	// Hostname contains a value of the hostname property.
+	// This value must only be only if the breakdown query was for this property.
+	Hostname string `json:"hostname"`
 }
 
 // BreakdownResultEntry represents an entry in a breakdown query result.
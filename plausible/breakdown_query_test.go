@@ -1,6 +1,7 @@
 package plausible
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -105,6 +106,60 @@ func TestUnitToQueryArgsBreakdownQuery(t *testing.T) {
 
 }
 
+func TestUnitBreakdownResultDecoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected PropertyResult
+	}{
+		{
+			name:     "breakdown by entry page",
+			response: `{"results":[{"entry_page":"/blog","visitors":3}]}`,
+			expected: PropertyResult{EntryPage: "/blog"},
+		},
+		{
+			name:     "breakdown by city, sent as a number",
+			response: `{"results":[{"city":2735943,"visitors":3}]}`,
+			expected: PropertyResult{City: "2735943"},
+		},
+		{
+			name:     "breakdown by region",
+			response: `{"results":[{"region":"PT-13","visitors":3}]}`,
+			expected: PropertyResult{Region: "PT-13"},
+		},
+		{
+			name:     "breakdown by goal",
+			response: `{"results":[{"goal":"Signup","visitors":3}]}`,
+			expected: PropertyResult{Goal: "Signup"},
+		},
+		{
+			name:     "breakdown by hostname",
+			response: `{"results":[{"hostname":"blog.example.com","visitors":3}]}`,
+			expected: PropertyResult{Hostname: "blog.example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		var raw rawBreakdownResponse
+		if err := json.Unmarshal([]byte(test.response), &raw); err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if len(raw.Results) != 1 {
+			t.Fatalf("test '%s' failed: expected 1 entry, got %d", test.name, len(raw.Results))
+		}
+
+		if raw.Results[0].PropertyResult != test.expected {
+			t.Fatalf("test '%s' failed: expected property result %+v, got %+v",
+				test.name, test.expected, raw.Results[0].PropertyResult)
+		}
+
+		if raw.Results[0].Visitors != 3 {
+			t.Fatalf("test '%s' failed: expected 3 visitors, got %d", test.name, raw.Results[0].Visitors)
+		}
+	}
+}
+
 func TestIntegrationBreakdownQuery(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -2,12 +2,15 @@ package plausible
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
 	"github.com/valyala/fasthttp"
@@ -16,14 +19,85 @@ import (
 // DefaultBaseURL contains the default base url for the plausible API.
 const DefaultBaseURL = "https://plausible.io/api/v1/"
 
+// DefaultUserAgent contains the default value of the User-Agent header sent in requests to the API.
+const DefaultUserAgent = "go-plausible"
+
 // Client handles the interaction with the plausible API.
 //
-// The client must be initialized with a token using either NewClient or NewClientWithBaseURL.
+// The client must be initialized with a token using either NewClient, NewClientWithBaseURL or NewClientWithOptions.
 // It's safe to use this client concurrently.
 type Client struct {
-	baseURL string
-	token   string
-	client  *fasthttp.Client
+	baseURL     string
+	token       string
+	userAgent   string
+	timeout     time.Duration
+	maxConns    int
+	transport   Transport
+	retryPolicy RetryPolicy
+	limiter     *rateLimiter
+}
+
+// ClientOption represents an option to configure a Client.
+// It is not meant to be created directly - instead, you should get
+// a ClientOption via the helper functions, like WithBaseURL, WithTimeout or WithHTTPClient.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the base URL of the API the client will use.
+// The url must be a complete url as it must contain a schema, the domain for the API and the prefix path of the
+// API, e.g. "https://plausible.io/api/v1/". Including a trailing / in the URL is optional.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithTimeout sets the maximum amount of time a request to the API can take.
+// The timeout applies to each attempt of a request and works together with the deadlines of the
+// contexts passed to the methods ending in Context, whichever expires first.
+// A timeout of zero, the default, means no timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent in the requests to the API.
+// This does not change the user agent of the events sent with PushEvent, which is part of the event request.
+// By default, the DefaultUserAgent is used.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithMaxConns sets the maximum number of connections the client keeps open to the API.
+// This option only applies to the default transport. When a transport is set with WithTransport,
+// WithHTTPClient or WithFastHTTPClient, the number of connections is up to the given client.
+func WithMaxConns(maxConns int) ClientOption {
+	return func(c *Client) {
+		c.maxConns = maxConns
+	}
+}
+
+// WithTransport sets the transport the client uses to send requests to the API.
+func WithTransport(transport Transport) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithHTTPClient makes the client send requests to the API with the given net/http client.
+// Use this option to configure proxies, custom TLS roots or http.RoundTripper middleware.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return WithTransport(NewNetHTTPTransport(httpClient))
+}
+
+// WithFastHTTPClient makes the client send requests to the API with the given fasthttp client.
+func WithFastHTTPClient(fastClient *fasthttp.Client) ClientOption {
+	return WithTransport(NewFastHTTPTransport(fastClient))
 }
 
 // NewClient returns a new API client with the given token.
@@ -32,12 +106,9 @@ type Client struct {
 //
 // This client will use the API located at https://plausible.io/api/v1/.
 // If you need to use another base URL for the API, create a client using NewClientWithBaseURL instead.
+// To further configure the client, use NewClientWithOptions.
 func NewClient(token string) *Client {
-	return &Client{
-		baseURL: DefaultBaseURL,
-		token:   token,
-		client:  &fasthttp.Client{},
-	}
+	return NewClientWithOptions(token)
 }
 
 // NewClientWithBaseURL creates a new API token with a given token, similarly to NewClient,
@@ -48,15 +119,43 @@ func NewClient(token string) *Client {
 // The url must be a complete url as it must contain a schema, the domain for the API and the prefix path of the
 // API, e.g. "https://plausible.io/api/v1/". Including a trailing / in the URL is optional.
 func NewClientWithBaseURL(token string, baseURL string) *Client {
-	if !strings.HasSuffix(baseURL, "/") {
-		baseURL += "/"
+	return NewClientWithOptions(token, WithBaseURL(baseURL))
+}
+
+// NewClientWithTransport returns a new API client with the given token, that sends the requests to the API with
+// the given transport. If the transport is nil, the default transport based on fasthttp is used.
+//
+// This is the same as calling NewClientWithOptions with the WithTransport option.
+func NewClientWithTransport(token string, transport Transport) *Client {
+	return NewClientWithOptions(token, WithTransport(transport))
+}
+
+// NewClientWithOptions returns a new API client with the given token, configured with the given options.
+// This function does not make any network requests.
+//
+// Without options, the client is the same as the one returned by NewClient. For instance, to create a client
+// for a self-hosted instance with a timeout for the requests:
+//
+//	client := plausible.NewClientWithOptions("<your_api_token>",
+//		plausible.WithBaseURL("https://plausible.example.com/api/v1/"),
+//		plausible.WithTimeout(10*time.Second),
+//	)
+func NewClientWithOptions(token string, options ...ClientOption) *Client {
+	c := &Client{
+		baseURL:   DefaultBaseURL,
+		token:     token,
+		userAgent: DefaultUserAgent,
+	}
+
+	for _, opt := range options {
+		opt(c)
 	}
 
-	return &Client{
-		baseURL: baseURL,
-		token:   token,
-		client:  &fasthttp.Client{},
+	if c.transport == nil {
+		c.transport = NewFastHTTPTransport(&fasthttp.Client{MaxConnsPerHost: c.maxConns})
 	}
+
+	return c
 }
 
 // BaseURL returns the base URL this client is using.
@@ -75,28 +174,40 @@ func (c *Client) Site(siteID string) *Site {
 	return &Site{
 		token:           c.token,
 		id:              siteID,
-		httpClient:      c.client,
 		plausibleClient: c,
 	}
 }
 
-// acquireRequest is a generic function for acquiring a new request that is already authenticated with the client token
-// and has the base URL set to the default base URL.
-func (c *Client) acquireRequest(method, endpoint string, queries QueryArgs, formData QueryArgs) (*fasthttp.Request, error) {
-	return c.acquireRequestWithBaseURl(c.baseURL, method, endpoint, queries, formData)
+// baseURLForVersion returns the base URL of the client for another version of the API, e.g. "v2".
+// It returns an error if the base URL of the client has no version segment, like "/api/v1/", to replace.
+func (c *Client) baseURLForVersion(version string) (string, error) {
+	if !apiVersionRegex.MatchString(c.baseURL) {
+		return "", fmt.Errorf("the base URL '%s' has no API version segment like '/api/v1/' to replace with '%s'",
+			c.baseURL, version)
+	}
+	return apiVersionRegex.ReplaceAllString(c.baseURL, "/api/"+version+"/"), nil
+}
+
+// newRequest is a generic function for building a new request that is already authenticated with the client token
+// and has the base URL set to the base URL of the client.
+func (c *Client) newRequest(method, endpoint string, queries QueryArgs, formData QueryArgs) (*HTTPRequest, error) {
+	return c.newRequestWithBaseURL(c.baseURL, method, endpoint, queries, formData)
 }
 
-// acquireRequest is the same as acquireRequest but allows the specification of a base URL.
-// This can be used for endpoints that do not follow the
-func (c *Client) acquireRequestWithBaseURl(baseURL, method, endpoint string, queries QueryArgs, formData QueryArgs) (*fasthttp.Request, error) {
-	req := fasthttp.AcquireRequest()
-	req.SetRequestURI(baseURL + endpoint)
-	req.Header.SetMethod(method)
-	req.Header.Add("Authorization", "Bearer "+c.token)
-	req.Header.Add("User-Agent", "go-plausible")
+// newRequestWithBaseURL is the same as newRequest but allows the specification of a base URL.
+// This can be used for endpoints that do not follow the base URL of the client, like the events API.
+func (c *Client) newRequestWithBaseURL(baseURL, method, endpoint string, queries QueryArgs, formData QueryArgs) (*HTTPRequest, error) {
+	req := &HTTPRequest{
+		Method:     method,
+		URL:        baseURL + endpoint,
+		Header:     http.Header{},
+		idempotent: isIdempotentMethod(method),
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", c.userAgent)
 
-	for _, q := range queries {
-		req.URI().QueryArgs().Add(q.Name, q.Value)
+	if queries.Count() > 0 {
+		req.URL += "?" + queries.encode()
 	}
 
 	if formData != nil && formData.Count() > 0 {
@@ -106,24 +217,21 @@ func (c *Client) acquireRequestWithBaseURl(baseURL, method, endpoint string, que
 		for _, q := range formData {
 			fw, err := mpwriter.CreateFormField(q.Name)
 			if err != nil {
-				fasthttp.ReleaseRequest(req)
-				return nil, fmt.Errorf("creating form field '%s': %v", q.Name, err)
+				return nil, fmt.Errorf("creating form field '%s': %w", q.Name, err)
 			}
 			_, err = io.Copy(fw, strings.NewReader(q.Value))
 			if err != nil {
-				fasthttp.ReleaseRequest(req)
-				return nil, fmt.Errorf("creating copying form field '%s' to form writer: %v", q.Name, err)
+				return nil, fmt.Errorf("creating copying form field '%s' to form writer: %w", q.Name, err)
 			}
 		}
 
 		err := mpwriter.Close()
 		if err != nil {
-			fasthttp.ReleaseRequest(req)
 			return nil, fmt.Errorf("closing form multipart writer: %w", err)
 		}
 
 		req.Header.Set("Content-Type", mpwriter.FormDataContentType())
-		req.SetBody(body.Bytes())
+		req.Body = body.Bytes()
 	}
 
 	return req, nil
@@ -134,18 +242,24 @@ func (c *Client) acquireRequestWithBaseURl(baseURL, method, endpoint string, que
 // Note: This endpoint requires an API token with permissions to use the sites provisioning API.
 // Check https://plausible.io/docs/sites-api for more info
 func (c *Client) CreateNewSite(siteRequest CreateSiteRequest) (CreateSiteResult, error) {
+	return c.CreateNewSiteContext(context.Background(), siteRequest)
+}
+
+// CreateNewSiteContext is like CreateNewSite, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) CreateNewSiteContext(ctx context.Context, siteRequest CreateSiteRequest) (CreateSiteResult, error) {
 	ok, invalidReason := siteRequest.Validate()
 	if !ok {
 		return CreateSiteResult{}, errors.New("invalid request for new site: " + invalidReason)
 	}
-	req, err := c.acquireRequest("POST", "sites", nil, siteRequest.toFormArgs())
+	req, err := c.newRequest("POST", "sites", nil, siteRequest.toFormArgs())
 	if err != nil {
-		return CreateSiteResult{}, fmt.Errorf("error acquiring request: %v", err)
+		return CreateSiteResult{}, fmt.Errorf("error building request: %w", err)
 	}
 
-	data, err := doRequest(c.client, req)
+	data, err := c.doRequest(ctx, req)
 	if err != nil {
-		return CreateSiteResult{}, fmt.Errorf("error performing request to create new site: %v", err)
+		return CreateSiteResult{}, fmt.Errorf("error performing request to create new site: %w", err)
 	}
 
 	var res CreateSiteResult
@@ -157,20 +271,29 @@ func (c *Client) CreateNewSite(siteRequest CreateSiteRequest) (CreateSiteResult,
 	return res, nil
 }
 
-// ListSites lists existing sites in Plausible
+// ListSites lists existing sites in Plausible.
+// The sites of a single team can be listed with the pagination.Team option.
 func (c *Client) ListSites(pagOptions ...pagination.Option) (ListSitesResult, error) {
+	return c.ListSitesContext(context.Background(), pagOptions...)
+}
 
+// ListSitesContext is like ListSites, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) ListSitesContext(ctx context.Context, pagOptions ...pagination.Option) (ListSitesResult, error) {
 	paginator := pagination.NewPaginator(pagOptions...)
 	qArgs := QueryArgsFromPaginator(paginator)
+	if paginator.TeamID != "" {
+		qArgs.Add(QueryArg{Name: "team_id", Value: paginator.TeamID})
+	}
 
-	req, err := c.acquireRequest("GET", "sites", qArgs, nil)
+	req, err := c.newRequest("GET", "sites", qArgs, nil)
 	if err != nil {
-		return ListSitesResult{}, fmt.Errorf("error acquiring request: %v", err)
+		return ListSitesResult{}, fmt.Errorf("error building request: %w", err)
 	}
 
-	data, err := doRequest(c.client, req)
+	data, err := c.doRequest(ctx, req)
 	if err != nil {
-		return ListSitesResult{}, fmt.Errorf("error performing request to list sites: %v", err)
+		return ListSitesResult{}, fmt.Errorf("error performing request to list sites: %w", err)
 	}
 
 	var res ListSitesResult
@@ -182,11 +305,84 @@ func (c *Client) ListSites(pagOptions ...pagination.Option) (ListSitesResult, er
 	return res, nil
 }
 
+// ListSitesIter returns an iterator over all the sites, starting at the page given by the pagination options.
+// Pages are only fetched when the iteration reaches them, following the After cursor of each page.
+// The Limit pagination option sets the number of sites in each page, and the Team option limits the sites to a team.
+func (c *Client) ListSitesIter(pagOptions ...pagination.Option) *SitesIterator {
+	return c.ListSitesIterContext(context.Background(), pagOptions...)
+}
+
+// ListSitesIterContext is like ListSitesIter, but the requests of the iterator are bound to the given context.
+// The requests are aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) ListSitesIterContext(ctx context.Context, pagOptions ...pagination.Option) *SitesIterator {
+	return newSitesIterator(ctx, c, pagOptions)
+}
+
+// ListAllSites lists all the sites in Plausible, fetching all the pages of sites.
+// The Limit pagination option sets the number of sites in each page, and the Team option limits the sites to a team.
+func (c *Client) ListAllSites(pagOptions ...pagination.Option) ([]SiteResult, error) {
+	return c.ListAllSitesContext(context.Background(), pagOptions...)
+}
+
+// ListAllSitesContext is like ListAllSites, but the requests are bound to the given context.
+// The requests are aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) ListAllSitesContext(ctx context.Context, pagOptions ...pagination.Option) ([]SiteResult, error) {
+	var sites []SiteResult
+
+	it := c.ListSitesIterContext(ctx, pagOptions...)
+	for it.Next() {
+		sites = append(sites, it.Site())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+// ListTeams lists the teams the API token can access.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (c *Client) ListTeams(pagOptions ...pagination.Option) (ListTeamsResult, error) {
+	return c.ListTeamsContext(context.Background(), pagOptions...)
+}
+
+// ListTeamsContext is like ListTeams, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) ListTeamsContext(ctx context.Context, pagOptions ...pagination.Option) (ListTeamsResult, error) {
+	paginator := pagination.NewPaginator(pagOptions...)
+
+	req, err := c.newRequest("GET", "sites/teams", QueryArgsFromPaginator(paginator), nil)
+	if err != nil {
+		return ListTeamsResult{}, fmt.Errorf("error building request: %w", err)
+	}
+
+	data, err := c.doRequest(ctx, req)
+	if err != nil {
+		return ListTeamsResult{}, fmt.Errorf("error performing request to list teams: %w", err)
+	}
+
+	var res ListTeamsResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return ListTeamsResult{}, fmt.Errorf("error parsing list teams response: %w", err)
+	}
+
+	return res, nil
+}
+
 // PushEvent records an event on plausible
 func (c *Client) PushEvent(ev EventRequest) ([]byte, error) {
-	req, err := c.acquireEventRequest(ev)
+	return c.PushEventContext(context.Background(), ev)
+}
+
+// PushEventContext is like PushEvent, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (c *Client) PushEventContext(ctx context.Context, ev EventRequest) ([]byte, error) {
+	req, err := c.newEventRequest(ev)
 	if err != nil {
 		return nil, fmt.Errorf("acquiring event request from client: %w", err)
 	}
-	return doRequest(c.client, req)
+	return c.doRequest(ctx, req)
 }
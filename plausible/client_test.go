@@ -1,6 +1,13 @@
 package plausible
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
 
 func TestUnitClientCreation(t *testing.T) {
 	tests := []struct {
@@ -27,6 +34,18 @@ func TestUnitClientCreation(t *testing.T) {
 			expectedToken:   "a",
 			expectedBaseURL: "https://mydomain.com/api/v1/",
 		},
+		{
+			name:            "valid client with options and without base URL",
+			client:          NewClientWithOptions("a"),
+			expectedToken:   "a",
+			expectedBaseURL: DefaultBaseURL,
+		},
+		{
+			name:            "valid client with options and custom base URL without trailing slash",
+			client:          NewClientWithOptions("a", WithBaseURL("https://mydomain.com/api/v1")),
+			expectedToken:   "a",
+			expectedBaseURL: "https://mydomain.com/api/v1/",
+		},
 	}
 
 	for _, test := range tests {
@@ -35,7 +54,66 @@ func TestUnitClientCreation(t *testing.T) {
 			t.Fatalf("test '%s' failed: expected token: %s, got: %s", test.name, test.expectedToken, token)
 		}
 		if url != test.expectedBaseURL {
-			t.Fatalf("test '%s' failed: expected base url: %s, got: %s", test.name, test.expectedToken, token)
+			t.Fatalf("test '%s' failed: expected base url: %s, got: %s", test.name, test.expectedBaseURL, url)
+		}
+	}
+}
+
+func TestUnitClientOptions(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.UserAgent()
+		if r.URL.Query().Get("site_id") == "slow.com" {
+			time.Sleep(500 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		client            *Client
+		site              string
+		expectedUserAgent string
+		expectedError     error
+	}{
+		{
+			name:              "client with the default user agent",
+			client:            NewClientWithOptions("a", WithBaseURL(server.URL), WithMaxConns(2)),
+			site:              "example.com",
+			expectedUserAgent: DefaultUserAgent,
+		},
+		{
+			name:              "client with a custom user agent",
+			client:            NewClientWithOptions("a", WithBaseURL(server.URL), WithUserAgent("my-agent")),
+			site:              "example.com",
+			expectedUserAgent: "my-agent",
+		},
+		{
+			name:          "client with a timeout shorter than the response time",
+			client:        NewClientWithOptions("a", WithBaseURL(server.URL), WithTimeout(50*time.Millisecond)),
+			site:          "slow.com",
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		gotUserAgent = ""
+		_, err := test.client.Site(test.site).CurrentVisitors()
+
+		if test.expectedError != nil {
+			if !errors.Is(err, test.expectedError) {
+				t.Fatalf("test '%s' failed: expected error %v, got %v", test.name, test.expectedError, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if gotUserAgent != test.expectedUserAgent {
+			t.Fatalf("test '%s' failed: expected user agent %s, got %s", test.name, test.expectedUserAgent, gotUserAgent)
 		}
 	}
 }
@@ -0,0 +1,178 @@
+package plausible
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationProblem describes a problem found when validating a query.
+type ValidationProblem struct {
+	// Field is the name of the field of the query where the problem was found, e.g "Metrics" or "Filters".
+	Field string
+	// Message describes the problem.
+	Message string
+}
+
+// ValidationError is the error returned when a query is not valid.
+// It lists all the problems found in the query, not just the first one.
+type ValidationError struct {
+	// Query is the type of the query that was validated: "aggregate", "breakdown" or "timeline".
+	Query string
+	// Problems contains all the problems found in the query.
+	Problems []ValidationProblem
+}
+
+// Error returns a description of the error with all the problems found.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s query: %s", e.Query, joinProblems(e.Problems))
+}
+
+func joinProblems(problems []ValidationProblem) string {
+	messages := make([]string, 0, len(problems))
+	for _, p := range problems {
+		messages = append(messages, p.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validationResult converts a list of problems to the values returned by the Validate methods.
+func validationResult(problems []ValidationProblem) (ok bool, invalidReason string) {
+	if len(problems) == 0 {
+		return true, ""
+	}
+	return false, joinProblems(problems)
+}
+
+// validationError converts a list of problems of a query to an error, or nil if there are no problems.
+func validationError(query string, problems []ValidationProblem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Query: query, Problems: problems}
+}
+
+// metricCompatibility describes the queries in which a metric can be used.
+type metricCompatibility struct {
+	// breakdownOnly tells whether the metric can only be used in breakdown queries.
+	breakdownOnly bool
+	// requires is the property the query must be filtered by or broken down by for the metric to be used.
+	requires PropertyName
+	// session tells whether the metric is a session metric, which can't be used in breakdowns by event properties.
+	session bool
+	// noEventFilters tells whether the metric can't be used in queries filtered by event properties.
+	noEventFilters bool
+}
+
+// metricsCompatibility is the compatibility table of the metrics declared in this package.
+var metricsCompatibility = map[Metric]metricCompatibility{
+	Visitors:            {},
+	PageViews:           {},
+	Visits:              {},
+	Events:              {},
+	BounceRate:          {session: true},
+	VisitDuration:       {session: true},
+	ViewsPerVisit:       {session: true, noEventFilters: true},
+	ConversionRate:      {requires: EventGoal},
+	GroupConversionRate: {breakdownOnly: true, requires: EventGoal},
+	TimeOnPage:          {requires: EventPage},
+	Percentage:          {breakdownOnly: true},
+	ScrollDepth:         {requires: EventPage},
+	TotalRevenue:        {requires: EventGoal},
+	AverageRevenue:      {requires: EventGoal},
+}
+
+// timeIntervalsPeriods contains, for each time interval declared in this package, the periods it can be used with.
+// Custom periods of any length are accepted for all intervals but minutes.
+var timeIntervalsPeriods = map[TimeInterval][]string{
+	MinuteInterval: {"30m", "day"},
+	HourInterval:   {"day", "7d", "custom"},
+	DateInterval:   {"day", "7d", "28d", "30d", "91d", "month", "6mo", "12mo", "year", "all", "custom"},
+	WeekInterval:   {"28d", "30d", "91d", "month", "6mo", "12mo", "year", "all", "custom"},
+	MonthInterval:  {"91d", "month", "6mo", "12mo", "year", "all", "custom"},
+}
+
+// isEventProperty tells whether a property is a property of events, like the page or the name of the event,
+// as opposed to a property of the visit. The hostname is recorded for both events and visits.
+func isEventProperty(name PropertyName) bool {
+	return strings.HasPrefix(string(name), "event:") && name != EventHostname
+}
+
+// queryValidator collects the problems found in a query.
+// Metrics and properties unknown to this package are not checked and are left for the API to validate,
+// as they may be valid in newer versions of the API.
+type queryValidator struct {
+	problems []ValidationProblem
+}
+
+func (v *queryValidator) addProblem(field string, format string, args ...interface{}) {
+	v.problems = append(v.problems, ValidationProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *queryValidator) checkFilters(filters Filter) {
+	for _, p := range filters.Properties {
+		if ok, invalidReason := p.Validate(); !ok {
+			v.addProblem("Filters", "%s", invalidReason)
+		}
+	}
+}
+
+// checkMetrics checks whether the metrics can be used in a query with the given filters.
+// For breakdown queries, breakdownProperty is the property of the breakdown.
+func (v *queryValidator) checkMetrics(metrics Metrics, filters Filter, breakdownProperty PropertyName) {
+	filtered := map[PropertyName]bool{}
+	for _, p := range filters.Properties {
+		filtered[p.Name] = true
+	}
+
+	for _, m := range metrics {
+		compat, known := metricsCompatibility[m]
+		if !known {
+			continue
+		}
+
+		if compat.breakdownOnly && breakdownProperty == "" {
+			v.addProblem("Metrics", "metric '%s' can only be used in breakdown queries", m)
+		}
+
+		if compat.requires != "" && !filtered[compat.requires] && breakdownProperty != compat.requires {
+			v.addProblem("Metrics", "metric '%s' requires a filter or a breakdown by '%s'", m, compat.requires)
+		}
+
+		if compat.session && isEventProperty(breakdownProperty) {
+			v.addProblem("Metrics", "session metric '%s' can't be used in a breakdown by '%s'", m, breakdownProperty)
+		}
+
+		if compat.noEventFilters {
+			for _, p := range filters.Properties {
+				if isEventProperty(p.Name) {
+					v.addProblem("Metrics", "metric '%s' can't be used with a filter by '%s'", m, p.Name)
+				}
+			}
+		}
+	}
+}
+
+// checkInterval checks whether the interval is known and can be used with the period.
+// Periods not supported by the API are not checked.
+func (v *queryValidator) checkInterval(interval TimeInterval, period TimePeriod) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	periods, known := timeIntervalsPeriods[interval]
+	if !known {
+		v.addProblem("Interval", "unknown interval '%s'", interval)
+		return
+	}
+
+	if _, supported := periodsByName[period.Period]; !supported && period.Period != "custom" {
+		return
+	}
+
+	for _, p := range periods {
+		if p == period.Period {
+			return
+		}
+	}
+	v.addProblem("Interval", "interval '%s' can't be used with the period '%s'", interval, period.Period)
+}
@@ -0,0 +1,222 @@
+package plausible
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnitQueryCompatibility(t *testing.T) {
+	tests := []struct {
+		name             string
+		check            func() error
+		expectedProblems []string
+	}{
+		{
+			name: "aggregate query with compatible metrics",
+			check: (&AggregateQuery{
+				Period:  DayPeriod(),
+				Metrics: Metrics{Visitors, ConversionRate, TotalRevenue},
+				Filters: NewFilter().ByEventGoal("Purchase"),
+			}).Check,
+		},
+		{
+			name: "aggregate query with conversion rate without a goal filter",
+			check: (&AggregateQuery{
+				Period:  DayPeriod(),
+				Metrics: Metrics{Visitors, ConversionRate},
+			}).Check,
+			expectedProblems: []string{
+				"metric 'conversion_rate' requires a filter or a breakdown by 'event:goal'",
+			},
+		},
+		{
+			name: "aggregate query with views per visit and an event filter",
+			check: (&AggregateQuery{
+				Period:  DayPeriod(),
+				Metrics: Metrics{ViewsPerVisit},
+				Filters: NewFilter().ByVisitOs("Linux").ByEventPage("/"),
+			}).Check,
+			expectedProblems: []string{
+				"metric 'views_per_visit' can't be used with a filter by 'event:page'",
+			},
+		},
+		{
+			name: "aggregate query with several problems",
+			check: (&AggregateQuery{
+				Metrics: Metrics{Percentage, Metric("sessions")},
+				Filters: NewFilter().Where(PropertyName("visit:planet"), FilterIs, "Mars"),
+			}).Check,
+			expectedProblems: []string{
+				"a period must be specified for an aggregate query",
+				"metric 'percentage' can only be used in breakdown queries",
+			},
+		},
+		{
+			name: "breakdown query with bounce rate by page",
+			check: (&BreakdownQuery{
+				Property: EventPage,
+				Period:   DayPeriod(),
+				Metrics:  Metrics{Visitors, BounceRate, TimeOnPage},
+			}).Check,
+			expectedProblems: []string{
+				"session metric 'bounce_rate' can't be used in a breakdown by 'event:page'",
+			},
+		},
+		{
+			name: "breakdown query by goal with conversion metrics",
+			check: (&BreakdownQuery{
+				Property: EventGoal,
+				Period:   DayPeriod(),
+				Metrics:  Metrics{Visitors, ConversionRate, GroupConversionRate, Percentage},
+			}).Check,
+		},
+		{
+			name: "breakdown query with a property and a metric unknown to the package",
+			check: (&BreakdownQuery{
+				Property: PropertyName("visit:planet"),
+				Period:   DayPeriod(),
+				Metrics:  Metrics{Metric("sessions")},
+			}).Check,
+		},
+		{
+			name: "timeseries query by minute over 12 months",
+			check: (&TimeseriesQuery{
+				Period:   Last12Months(),
+				Interval: MinuteInterval,
+			}).Check,
+			expectedProblems: []string{
+				"interval 'minute' can't be used with the period '12mo'",
+			},
+		},
+		{
+			name: "timeseries query by week over a day",
+			check: (&TimeseriesQuery{
+				Period:   DayPeriod(),
+				Interval: WeekInterval,
+			}).Check,
+			expectedProblems: []string{
+				"interval 'week' can't be used with the period 'day'",
+			},
+		},
+		{
+			name: "timeseries queries with valid intervals",
+			check: func() error {
+				quarter, err := QuarterPeriod(2021, 2)
+				if err != nil {
+					return err
+				}
+
+				queries := []TimeseriesQuery{
+					{Period: RealtimePeriod(), Interval: MinuteInterval},
+					{Period: DayPeriod(), Interval: HourInterval},
+					{Period: DayPeriod(), Interval: DateInterval},
+					{Period: Last7Days(), Interval: HourInterval},
+					{Period: Last91Days(), Interval: WeekInterval},
+					{Period: YearPeriod(), Interval: MonthInterval},
+					{Period: quarter, Interval: WeekInterval},
+				}
+				for _, q := range queries {
+					if err := q.Check(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "timeseries query with an unknown interval and scroll depth without a page filter",
+			check: (&TimeseriesQuery{
+				Period:   Last7Days(),
+				Interval: TimeInterval("fortnight"),
+				Metrics:  Metrics{ScrollDepth},
+			}).Check,
+			expectedProblems: []string{
+				"unknown interval 'fortnight'",
+				"metric 'scroll_depth' requires a filter or a breakdown by 'event:page'",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		err := test.check()
+		if len(test.expectedProblems) == 0 {
+			if err != nil {
+				t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("test '%s' failed: expected a *ValidationError, got %v", test.name, err)
+		}
+
+		if len(validationErr.Problems) != len(test.expectedProblems) {
+			t.Fatalf("test '%s' failed: expected %d problems, got %d: %v",
+				test.name, len(test.expectedProblems), len(validationErr.Problems), err)
+		}
+
+		for i, p := range validationErr.Problems {
+			if p.Message != test.expectedProblems[i] {
+				t.Fatalf("test '%s' failed: expected problem '%s', got '%s'", test.name, test.expectedProblems[i], p.Message)
+			}
+		}
+	}
+}
+
+func TestUnitValidationErrorMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		check           func() error
+		expectedMessage string
+	}{
+		{
+			name:            "aggregate query without period",
+			check:           (&AggregateQuery{Metrics: Metrics{Visitors}}).Check,
+			expectedMessage: "invalid aggregate query: a period must be specified for an aggregate query",
+		},
+		{
+			name:            "breakdown query without property",
+			check:           (&BreakdownQuery{Period: DayPeriod()}).Check,
+			expectedMessage: "invalid breakdown query: a property must be specified for a breakdown query",
+		},
+		{
+			name:            "timeseries query without period",
+			check:           (&TimeseriesQuery{}).Check,
+			expectedMessage: "invalid timeline query: a period must be specified for a timeseries query",
+		},
+	}
+
+	for _, test := range tests {
+		err := test.check()
+		if err == nil || err.Error() != test.expectedMessage {
+			t.Fatalf("test '%s' failed: expected error '%s', got %v", test.name, test.expectedMessage, err)
+		}
+	}
+}
+
+func TestUnitInvalidQueryIsNotSent(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+	_, err := site.Breakdown(BreakdownQuery{
+		Property: EventName,
+		Period:   DayPeriod(),
+		Metrics:  Metrics{VisitDuration, ConversionRate},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Query != "breakdown" || len(validationErr.Problems) != 2 {
+		t.Fatalf("expected a *ValidationError with 2 problems for the breakdown query, got %v", err)
+	}
+
+	if requests != 0 {
+		t.Fatalf("expected no requests to be sent, got %d", requests)
+	}
+}
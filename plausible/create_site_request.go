@@ -8,6 +8,9 @@ type CreateSiteRequest struct {
 	// Timezone name according to the IANA database (e.g "Europe/London").
 	// This field is optional and will default to "Etc/UTC".
 	Timezone string
+	// TeamID is the ID of the team where the site is created, as given by Client.ListTeams.
+	// This field is optional and the site will be created in the default team of the API token if it's empty.
+	TeamID string
 }
 
 func (csr *CreateSiteRequest) toFormArgs() QueryArgs {
@@ -19,6 +22,10 @@ func (csr *CreateSiteRequest) toFormArgs() QueryArgs {
 		res = append(res, QueryArg{Name: "timezone", Value: csr.Timezone})
 	}
 
+	if csr.TeamID != "" {
+		res = append(res, QueryArg{Name: "team_id", Value: csr.TeamID})
+	}
+
 	return res
 }
 
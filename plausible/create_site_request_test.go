@@ -61,6 +61,19 @@ func TestUnitToFormArgsNewSiteRequest(t *testing.T) {
 				QueryArg{Name: "timezone", Value: "Europe/Lisbon"},
 			},
 		},
+		{
+			name: "valid new site request with domain, timezone and team",
+			request: CreateSiteRequest{
+				Domain:   "mydomain.com",
+				Timezone: "Europe/Lisbon",
+				TeamID:   "team-1",
+			},
+			expectedFormArgs: QueryArgs{
+				QueryArg{Name: "domain", Value: "mydomain.com"},
+				QueryArg{Name: "timezone", Value: "Europe/Lisbon"},
+				QueryArg{Name: "team_id", Value: "team-1"},
+			},
+		},
 		{
 			name: "valid new site request with domain and without timezone",
 			request: CreateSiteRequest{
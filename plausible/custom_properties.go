@@ -0,0 +1,61 @@
+package plausible
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CustomPropertyResult contains the details of a custom property enabled on a site.
+type CustomPropertyResult struct {
+	// Property is the name of the custom property, without the "event:props:" prefix.
+	Property string `json:"property"`
+}
+
+// ListCustomPropertiesResult is the result of a request to list the custom properties enabled on a site.
+type ListCustomPropertiesResult struct {
+	// CustomProperties is the list of custom properties enabled on the site.
+	CustomProperties []CustomPropertyResult `json:"custom_properties"`
+}
+
+// Has tells whether a custom property is in the list.
+func (r ListCustomPropertiesResult) Has(property string) bool {
+	property = customPropertyBareName(property)
+	for _, p := range r.CustomProperties {
+		if p.Property == property {
+			return true
+		}
+	}
+	return false
+}
+
+// AddCustomPropertyResult is the result of a request to enable a custom property on a site.
+type AddCustomPropertyResult struct {
+	// Created tells whether the custom property was enabled.
+	Created bool `json:"created"`
+}
+
+// RemoveCustomPropertyResult is the result of a request to disable a custom property on a site.
+type RemoveCustomPropertyResult struct {
+	// Deleted tells whether the custom property was disabled.
+	Deleted bool `json:"deleted"`
+}
+
+// customPropertyBareName returns the name of a custom property without the "event:props:" prefix,
+// so that the names given to CustomPropertyName can also be used in the provisioning API.
+func customPropertyBareName(property string) string {
+	return strings.TrimPrefix(property, customPropertyPrefix)
+}
+
+// validateCustomPropertyName tells whether a custom property name is valid for the provisioning API.
+// If the name is not valid, a string explaining why will be returned.
+func validateCustomPropertyName(property string) (bool, string) {
+	if customPropertyBareName(property) == "" {
+		return false, "a custom property name must be specified"
+	}
+	return true, ""
+}
+
+// customPropertyPath returns the endpoint of a custom property of a site.
+func customPropertyPath(property string) string {
+	return "sites/custom-props/" + url.PathEscape(customPropertyBareName(property))
+}
@@ -0,0 +1,122 @@
+package plausible
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newCustomPropertiesServer returns a server that keeps the custom properties of a site in a set.
+func newCustomPropertiesServer(enabled map[string]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			res := ListCustomPropertiesResult{CustomProperties: []CustomPropertyResult{}}
+			for property := range enabled {
+				res.CustomProperties = append(res.CustomProperties, CustomPropertyResult{Property: property})
+			}
+			_ = json.NewEncoder(w).Encode(res)
+		case "PUT":
+			enabled[r.FormValue("property")] = true
+			_, _ = w.Write([]byte(`{"created":true}`))
+		case "DELETE":
+			delete(enabled, strings.TrimPrefix(r.URL.Path, "/sites/custom-props/"))
+			_, _ = w.Write([]byte(`{"deleted":true}`))
+		}
+	}))
+}
+
+func TestUnitEnsureCustomProperties(t *testing.T) {
+	tests := []struct {
+		name            string
+		enabled         []string
+		properties      []string
+		expectedAdded   string
+		expectedEnabled string
+	}{
+		{
+			name:            "some properties already enabled",
+			enabled:         []string{"author"},
+			properties:      []string{"author", "logged_in", "plan"},
+			expectedAdded:   "logged_in,plan",
+			expectedEnabled: "author,logged_in,plan",
+		},
+		{
+			name:            "all properties already enabled",
+			enabled:         []string{"author", "plan"},
+			properties:      []string{"plan"},
+			expectedAdded:   "",
+			expectedEnabled: "author,plan",
+		},
+		{
+			name:            "properties with the custom property prefix and repeated",
+			properties:      []string{string(CustomPropertyName("author")), "author"},
+			expectedAdded:   "author",
+			expectedEnabled: "author",
+		},
+	}
+
+	for _, test := range tests {
+		enabled := map[string]bool{}
+		for _, property := range test.enabled {
+			enabled[property] = true
+		}
+		server := newCustomPropertiesServer(enabled)
+
+		added, err := NewClientWithBaseURL("token", server.URL).Site("example.com").
+			EnsureCustomProperties(test.properties...)
+		server.Close()
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if strings.Join(added, ",") != test.expectedAdded {
+			t.Fatalf("test '%s' failed: expected to add %s, added %s", test.name, test.expectedAdded, strings.Join(added, ","))
+		}
+
+		var got []string
+		for property := range enabled {
+			got = append(got, property)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != test.expectedEnabled {
+			t.Fatalf("test '%s' failed: expected enabled properties %s, got %s",
+				test.name, test.expectedEnabled, strings.Join(got, ","))
+		}
+	}
+}
+
+func TestUnitCustomPropertyRequests(t *testing.T) {
+	enabled := map[string]bool{"logged in": true, "plan": true}
+	server := newCustomPropertiesServer(enabled)
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	removed, err := site.RemoveCustomProperty("logged in")
+	if err != nil {
+		t.Fatalf("unexpected error removing the custom property: %v", err)
+	}
+	if !removed.Deleted || enabled["logged in"] {
+		t.Fatalf("expected the custom property to be removed")
+	}
+
+	list, err := site.ListCustomProperties()
+	if err != nil {
+		t.Fatalf("unexpected error listing the custom properties: %v", err)
+	}
+	if len(list.CustomProperties) != 1 || !list.Has("plan") || list.Has("logged in") {
+		t.Fatalf("unexpected list of custom properties %+v", list)
+	}
+
+	if _, err := site.AddCustomProperty(""); err == nil {
+		t.Fatalf("expected an error adding a custom property without name")
+	}
+
+	if _, err := site.EnsureCustomProperties("plan", string(CustomPropertyName(""))); err == nil {
+		t.Fatalf("expected an error ensuring a custom property without name")
+	}
+}
@@ -1,6 +1,18 @@
 package plausible
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// dateLayout is the layout of dates in the API, "yyyy-mm-dd".
+	dateLayout = "2006-01-02"
+	// timeLayout is the layout of times of day in the API, "hh:mm:ss".
+	timeLayout = "15:04:05"
+	// dateTimeLayout is the layout of dates with a time of day in the API, "yyyy-mm-dd hh:mm:ss".
+	dateTimeLayout = dateLayout + " " + timeLayout
+)
 
 // DateTime contains basic information about a date and a time.
 // It represents the information about date and time returned by some API calls.
@@ -8,23 +20,77 @@ import "fmt"
 // This basic information about the date includes the day of month, month, year.
 // The information about the time includes the hour, minute and second.
 // It does not contain any information about timezones.
+//
+// DateTime values are encoded to JSON and text in the "yyyy-mm-dd hh:mm:ss" format.
 type DateTime struct {
 	Date
 	Time
 }
 
+// DateTimeFromTime makes a DateTime with the date and the time of day of a time.Time, in the location of the time.Time.
+func DateTimeFromTime(t time.Time) DateTime {
+	return DateTime{Date: DateFromTime(t), Time: TimeFromTime(t)}
+}
+
+// ParseDateTime parses a date and a time of day in the "yyyy-mm-dd hh:mm:ss" format.
+// A date in the "yyyy-mm-dd" format is also accepted, in which case the time of day is midnight.
+func ParseDateTime(value string) (DateTime, error) {
+	layout := dateTimeLayout
+	if len(value) == len(dateLayout) {
+		layout = dateLayout
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return DateTime{}, fmt.Errorf("invalid date '%s', expected the format yyyy-mm-dd or yyyy-mm-dd hh:mm:ss", value)
+	}
+
+	return DateTimeFromTime(t), nil
+}
+
 // String converts the DateTime into a human-readable string.
-func (dt *DateTime) String() string {
+func (dt DateTime) String() string {
 	return dt.toPlausibleFormat()
 }
 
 // toPlausibleFormat converts the DateTime to a string format that the plausible API uses.
-func (dt *DateTime) toPlausibleFormat() string {
+func (dt DateTime) toPlausibleFormat() string {
 	return fmt.Sprintf("%s %s", dt.Date.toPlausibleFormat(), dt.Time.toPlausibleFormat())
 }
 
+// ToTime converts the DateTime to a time.Time in the given location.
+// If the location is nil, UTC is used.
+func (dt DateTime) ToTime(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(dt.Year, time.Month(dt.Month), dt.Day, dt.Hour, dt.Minute, dt.Second, 0, loc)
+}
+
+// IsValid tells whether both the date and the time of day are valid.
+func (dt DateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Time.IsValid()
+}
+
+// MarshalText encodes the DateTime in the "yyyy-mm-dd hh:mm:ss" format.
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.toPlausibleFormat()), nil
+}
+
+// UnmarshalText decodes a DateTime in the formats accepted by ParseDateTime.
+func (dt *DateTime) UnmarshalText(text []byte) error {
+	parsed, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
+
 // Time represents the basic information about a time of day.
 // It represents the information about time returned by some API calls.
+//
+// Time values are encoded to JSON and text in the "hh:mm:ss" format.
 type Time struct {
 	// Hour of a given time of day
 	Hour int
@@ -34,33 +100,157 @@ type Time struct {
 	Second int
 }
 
+// TimeFromTime makes a Time with the time of day of a time.Time, in the location of the time.Time.
+func TimeFromTime(t time.Time) Time {
+	return Time{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}
+}
+
 // String converts the Time into a human-readable string.
-func (t *Time) String() string {
+func (t Time) String() string {
 	return t.toPlausibleFormat()
 }
 
 // toPlausibleFormat converts the Time to a string format that the plausible API uses.
-func (t *Time) toPlausibleFormat() string {
+func (t Time) toPlausibleFormat() string {
 	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
 }
 
+// IsValid tells whether the time is a valid time of day, from 00:00:00 to 23:59:59.
+func (t Time) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 && t.Second >= 0 && t.Second < 60
+}
+
+// MarshalText encodes the Time in the "hh:mm:ss" format.
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.toPlausibleFormat()), nil
+}
+
+// UnmarshalText decodes a Time in the "hh:mm:ss" format.
+func (t *Time) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse(timeLayout, string(text))
+	if err != nil {
+		return fmt.Errorf("invalid time '%s', expected the format hh:mm:ss", text)
+	}
+	*t = TimeFromTime(parsed)
+	return nil
+}
+
 // Date represents the basic information about a date.
 // It represents the information about date returned by some API calls.
+//
+// Date values are encoded to JSON and text in the "yyyy-mm-dd" format.
 type Date struct {
 	// Day of month of the date (1-31)
 	Day int
 	// Month of the date (1-12)
 	Month int
-	// Year of the date (1-12)
+	// Year of the date
 	Year int
 }
 
+// DateFromTime makes a Date with the date of a time.Time, in the location of the time.Time.
+func DateFromTime(t time.Time) Date {
+	return Date{Day: t.Day(), Month: int(t.Month()), Year: t.Year()}
+}
+
+// ParseDate parses a date in the "yyyy-mm-dd" format.
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date '%s', expected the format yyyy-mm-dd", value)
+	}
+	return DateFromTime(t), nil
+}
+
 // String converts the Date into a human-readable string.
-func (d *Date) String() string {
+func (d Date) String() string {
 	return d.toPlausibleFormat()
 }
 
 // toPlausibleFormat converts the Date to a string format that the plausible API uses.
-func (d *Date) toPlausibleFormat() string {
+func (d Date) toPlausibleFormat() string {
 	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
 }
+
+// ToTime converts the Date to a time.Time at midnight in the given location.
+// If the location is nil, UTC is used.
+func (d Date) ToTime(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsValid tells whether the date exists in the calendar, e.g the 29th of February is only valid in leap years.
+func (d Date) IsValid() bool {
+	if d.Year < 1 || d.Month < 1 || d.Month > 12 || d.Day < 1 {
+		return false
+	}
+	return d.Day <= daysIn(time.Month(d.Month), d.Year)
+}
+
+// AddDays returns the date a number of days after the date, or before it if the number is negative.
+func (d Date) AddDays(days int) Date {
+	return DateFromTime(d.ToTime(time.UTC).AddDate(0, 0, days))
+}
+
+// AddMonths returns the date a number of months after the date, or before it if the number is negative.
+// If the day of month doesn't exist in the resulting month, the last day of that month is used,
+// e.g adding a month to the 31st of January results in the last day of February.
+func (d Date) AddMonths(months int) Date {
+	firstOfMonth := time.Date(d.Year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+
+	res := DateFromTime(firstOfMonth)
+	res.Day = d.Day
+	if last := daysIn(firstOfMonth.Month(), firstOfMonth.Year()); res.Day > last {
+		res.Day = last
+	}
+	return res
+}
+
+// Before tells whether the date is before another date.
+func (d Date) Before(other Date) bool {
+	return d.compare(other) < 0
+}
+
+// After tells whether the date is after another date.
+func (d Date) After(other Date) bool {
+	return d.compare(other) > 0
+}
+
+// Equal tells whether the date is the same as another date.
+func (d Date) Equal(other Date) bool {
+	return d.compare(other) == 0
+}
+
+// compare returns a negative number if the date is before the other date, a positive number
+// if it is after and 0 if the dates are the same.
+func (d Date) compare(other Date) int {
+	if d.Year != other.Year {
+		return d.Year - other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month - other.Month
+	}
+	return d.Day - other.Day
+}
+
+// MarshalText encodes the Date in the "yyyy-mm-dd" format.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.toPlausibleFormat()), nil
+}
+
+// UnmarshalText decodes a Date in the "yyyy-mm-dd" format.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// daysIn returns the number of days of a month of a year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
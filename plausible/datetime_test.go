@@ -1,6 +1,10 @@
 package plausible
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
 
 func TestUnitTime(t *testing.T) {
 
@@ -153,3 +157,169 @@ func TestUnitDateTime(t *testing.T) {
 	}
 
 }
+
+func TestUnitDateConversions(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	tm := time.Date(2021, time.March, 28, 23, 30, 15, 0, lisbon)
+
+	date := DateFromTime(tm)
+	if date != (Date{Day: 28, Month: 3, Year: 2021}) {
+		t.Fatalf("expected date 2021-03-28, got %v", date)
+	}
+
+	dt := DateTimeFromTime(tm)
+	if dt.String() != "2021-03-28 23:30:15" {
+		t.Fatalf("expected date time 2021-03-28 23:30:15, got %v", dt)
+	}
+
+	if !dt.ToTime(lisbon).Equal(tm) {
+		t.Fatalf("expected date time to convert back to %v, got %v", tm, dt.ToTime(lisbon))
+	}
+
+	if got := date.ToTime(nil); !got.Equal(time.Date(2021, time.March, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected date to convert to midnight in UTC, got %v", got)
+	}
+}
+
+func TestUnitParseDate(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		expectedDate Date
+		shouldFail   bool
+	}{
+		{name: "valid date", value: "2021-05-01", expectedDate: Date{Day: 1, Month: 5, Year: 2021}},
+		{name: "leap day", value: "2020-02-29", expectedDate: Date{Day: 29, Month: 2, Year: 2020}},
+		{name: "leap day in a common year", value: "2021-02-29", shouldFail: true},
+		{name: "date with time", value: "2021-05-01 10:00:00", shouldFail: true},
+		{name: "empty date", value: "", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseDate(test.value)
+		if test.shouldFail {
+			if err == nil {
+				t.Fatalf("test '%s' failed: expected an error, got %v", test.name, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if got != test.expectedDate {
+			t.Fatalf("test '%s' failed: expected %v, got %v", test.name, test.expectedDate, got)
+		}
+	}
+}
+
+func TestUnitDateArithmetic(t *testing.T) {
+	tests := []struct {
+		name         string
+		got          Date
+		expectedDate Date
+	}{
+		{
+			name:         "add days across a month",
+			got:          Date{Day: 30, Month: 1, Year: 2021}.AddDays(3),
+			expectedDate: Date{Day: 2, Month: 2, Year: 2021},
+		},
+		{
+			name:         "subtract days across a year",
+			got:          Date{Day: 1, Month: 1, Year: 2021}.AddDays(-1),
+			expectedDate: Date{Day: 31, Month: 12, Year: 2020},
+		},
+		{
+			name:         "add a month to the end of a long month",
+			got:          Date{Day: 31, Month: 1, Year: 2020}.AddMonths(1),
+			expectedDate: Date{Day: 29, Month: 2, Year: 2020},
+		},
+		{
+			name:         "subtract months across a year",
+			got:          Date{Day: 15, Month: 2, Year: 2021}.AddMonths(-3),
+			expectedDate: Date{Day: 15, Month: 11, Year: 2020},
+		},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expectedDate {
+			t.Fatalf("test '%s' failed: expected %v, got %v", test.name, test.expectedDate, test.got)
+		}
+	}
+
+	earlier, later := Date{Day: 31, Month: 12, Year: 2020}, Date{Day: 1, Month: 1, Year: 2021}
+	if !earlier.Before(later) || earlier.After(later) || !later.After(earlier) || earlier.Equal(later) || !later.Equal(later) {
+		t.Fatalf("unexpected comparison between %v and %v", earlier, later)
+	}
+}
+
+func TestUnitDateValidity(t *testing.T) {
+	tests := []struct {
+		name    string
+		valid   bool
+		isValid bool
+	}{
+		{name: "valid date", valid: Date{Day: 31, Month: 12, Year: 2021}.IsValid(), isValid: true},
+		{name: "leap day", valid: Date{Day: 29, Month: 2, Year: 2024}.IsValid(), isValid: true},
+		{name: "leap day in a common year", valid: Date{Day: 29, Month: 2, Year: 2100}.IsValid(), isValid: false},
+		{name: "31st of April", valid: Date{Day: 31, Month: 4, Year: 2021}.IsValid(), isValid: false},
+		{name: "month 13", valid: Date{Day: 1, Month: 13, Year: 2021}.IsValid(), isValid: false},
+		{name: "zero date", valid: Date{}.IsValid(), isValid: false},
+		{name: "valid time", valid: Time{Hour: 23, Minute: 59, Second: 59}.IsValid(), isValid: true},
+		{name: "hour 24", valid: Time{Hour: 24}.IsValid(), isValid: false},
+		{
+			name:    "date time with an invalid time",
+			valid:   DateTime{Date: Date{Day: 1, Month: 1, Year: 2021}, Time: Time{Minute: 60}}.IsValid(),
+			isValid: false,
+		},
+	}
+
+	for _, test := range tests {
+		if test.valid != test.isValid {
+			t.Fatalf("test '%s' failed: expected validity %v, got %v", test.name, test.isValid, test.valid)
+		}
+	}
+}
+
+func TestUnitDateJSON(t *testing.T) {
+	type config struct {
+		Start   Date      `json:"start"`
+		At      DateTime  `json:"at"`
+		Time    Time      `json:"time"`
+		Pointer *DateTime `json:"pointer"`
+	}
+
+	c := config{
+		Start: Date{Day: 1, Month: 5, Year: 2021},
+		At:    DateTime{Date: Date{Day: 2, Month: 5, Year: 2021}, Time: Time{Hour: 10, Minute: 5}},
+		Time:  Time{Hour: 8, Minute: 30},
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"start":"2021-05-01","at":"2021-05-02 10:05:00","time":"08:30:00","pointer":null}`
+	if string(data) != expected {
+		t.Fatalf("expected JSON %s, got %s", expected, data)
+	}
+
+	var decoded config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded != c {
+		t.Fatalf("expected %+v, got %+v", c, decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"start":"2021-02-30"}`), &decoded); err == nil {
+		t.Fatalf("expected an error decoding an invalid date")
+	}
+}
@@ -18,6 +18,17 @@ Start by creating a client to make requests. Then, request handlers to specific
 
     // Use 'mysite' and the 'myothersite' handlers to query stats for the sites
 
+HTTP transports
+
+By default, clients send requests with fasthttp. To use a net/http client instead, for instance to go through a proxy,
+to trust custom TLS roots or to add http.RoundTripper middleware, create the client with the WithHTTPClient option:
+
+    client := plausible.NewClientWithOptions("<your_api_token>", plausible.WithHTTPClient(&http.Client{
+        Transport: myRoundTripper,
+    }))
+
+Any implementation of the Transport interface can also be used with the WithTransport option.
+
 Queries - Stats API
 
 You can use the site handlers to perform queries / requests for data. There are four types of queries: current visitor queries,
@@ -41,10 +52,58 @@ A breakdown query reports stats for the value of a given property over a period
 "over the last 7 days what are the number of visitors and page views for each page of my site" falls into this category.
 Check the method Breakdown for more information and for examples of this query.
 
+The v2 Stats API has a single query endpoint that can do what all the queries above do, and also break down the results
+by more than one property at once. Use the method Query with a QueryV2 to make these queries.
+
+Cancellation and deadlines
+
+Every method that makes a request to the API has a variant with the Context suffix that takes a context.Context
+as its first argument, e.g. AggregateContext or ListSitesContext. The request is aborted as soon as the context is
+cancelled or its deadline expires, in which case the error returned wraps the error of the context:
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    result, err := mysite.AggregateContext(ctx, query)
+
+Errors
+
+When the API responds with an error, the error returned is an *APIError with the status code, the message and the
+body of the response. The class of the error can be checked with errors.Is and the sentinel errors of this package:
+
+    _, err := mysite.Aggregate(query)
+    if errors.Is(err, plausible.ErrRateLimited) {
+        var apiErr *plausible.APIError
+        if errors.As(err, &apiErr) {
+            // wait for apiErr.RetryAfter before trying again
+        }
+    }
+
+Queries are validated before they are sent. Besides the mandatory fields, the compatibility of the metrics with the
+filters, the properties and the intervals of the query is checked, e.g. the ConversionRate metric requires a filter
+by goal. Metrics and properties not declared in this package are left for the API to validate. When a query is not
+valid, the error returned is a *ValidationError that lists all the problems found:
+
+    _, err := mysite.Breakdown(query)
+    var validationErr *plausible.ValidationError
+    if errors.As(err, &validationErr) {
+        for _, problem := range validationErr.Problems {
+            fmt.Println(problem.Field, problem.Message)
+        }
+    }
+
 Provisioning API
 
 The provisioning API allows to create new sites on Plausible and to create shared links for those sites.
-The methods CreateNewSite and SharedLink respectively implement these requests.
+The methods CreateNewSite and SharedLink respectively implement these requests. Existing sites can be changed
+with Site.Update and deleted with Site.Delete. The goals of a site are managed with Site.CreateGoal,
+Site.ListGoals and Site.DeleteGoal, and its custom properties with Site.ListCustomProperties,
+Site.AddCustomProperty, Site.RemoveCustomProperty and Site.EnsureCustomProperties. Guests are invited to a site
+with Site.InviteGuest, listed with Site.ListGuests and removed with Site.RemoveGuest.
+
+Accounts with more than one team can list their teams with ListTeams, create sites in a team with the TeamID field
+of CreateSiteRequest and list the sites of a team with the pagination.Team option of ListSites, ListSitesIter and
+ListAllSites.
 
 However, please note that these methods are using the provisioning API which requires a token with special permissions
 for the requests to succeed. For more info: https://plausible.io/docs/sites-api
@@ -86,13 +145,17 @@ visit duration and bounce rate.
 These metrics are represented by the Metric type and there are four constants of this type,
 each one representing one of the four metrics: Visitors, PageViews, BounceRate and VisitDuration.
 
+There are also metrics for visits, events, goals, pages and revenue, like Visits, Events, ConversionRate, TimeOnPage
+and TotalRevenue. Check the Metric constants for the full list.
+
 Time Intervals
 
 Time intervals are used for time series queries to specify the interval of time between two consecutive data points.
 
 A time interval is represented by the TimeInterval type.
-There are currently two time intervals supported: date and month which are represented with the DateInterval
-and MonthInterval constants respectively.
+There are currently five time intervals supported: minute, hour, date, week and month which are represented with the
+MinuteInterval, HourInterval, DateInterval, WeekInterval and MonthInterval constants respectively. Each interval can
+only be used with some time periods, e.g. the MinuteInterval can't be used over the last 12 months.
 
 A MonthInterval means a month of difference between data points. For instance, if you ask for time series data
 over the last 6 months with a month interval, this means you will get 6 data points back - 1 for each month.
@@ -101,7 +164,7 @@ A DateInterval, depending on the query, means a day or an hour of difference bet
 you ask for time series data over the last 30 days with a date interval, you will get 30 data points back - 1 for each day.
 However, with a DateInterval, when the period of the time series refers to a day, for instance "today", the data points will
 actually have 1 hour of interval between them. You can check the Date string field of each data point to know
-about which date/hour the data refers to.
+about which date/hour the data refers to, or parse it with the DateTime method of the data point.
 
 Useful Links
 
@@ -0,0 +1,103 @@
+package plausible
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Errors that classify the errors returned by the API.
+// Use errors.Is to check if an error returned by this package belongs to one of these classes,
+// and errors.As with an *APIError to get the details of the response.
+var (
+	// ErrBadRequest is the class of errors for requests the API considered invalid (HTTP 400).
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is the class of errors for requests with a missing or invalid token (HTTP 401).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPaymentRequired is the class of errors for requests not allowed by the current plan (HTTP 402).
+	ErrPaymentRequired = errors.New("payment required")
+	// ErrForbidden is the class of errors for requests the token has no permission to make (HTTP 403).
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is the class of errors for requests about resources that don't exist,
+	// like an unknown site (HTTP 404).
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited is the class of errors for requests rejected because the rate limit
+	// of the token was exceeded (HTTP 429).
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError is the class of errors for requests that failed due to a problem in the API (HTTP 5xx).
+	ErrServerError = errors.New("server error")
+)
+
+// APIError represents an error response from the API.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message sent by the API, if any.
+	Message string
+	// RawBody is the body of the response.
+	RawBody []byte
+	// RetryAfter is the amount of time the API asked to wait before making another request,
+	// taken from the Retry-After header of the response. It's zero if the header is not present.
+	RetryAfter time.Duration
+}
+
+// Error returns a description of the error.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("non-ok code received (%d) from the API", e.StatusCode)
+	}
+
+	return fmt.Sprintf("api error with code %d: %s", e.StatusCode, e.Message)
+}
+
+// Is tells whether the error belongs to the given class of errors, e.g. ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	class := e.class()
+	return class != nil && target == class
+}
+
+// class returns the class of errors the error belongs to, or nil if it does not belong to a known one.
+func (e *APIError) class() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusPaymentRequired:
+		return ErrPaymentRequired
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500 && e.StatusCode <= 599:
+		return ErrServerError
+	}
+
+	return nil
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which can either be a number of seconds
+// or an HTTP date. It returns zero if the value is empty or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+
+	return date.Sub(now)
+}
@@ -0,0 +1,126 @@
+package plausible
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUnitAPIErrors(t *testing.T) {
+	tests := []struct {
+		name               string
+		status             int
+		body               string
+		retryAfter         string
+		expectedClass      error
+		expectedMessage    string
+		expectedRetryAfter time.Duration
+	}{
+		{
+			name:            "invalid token",
+			status:          http.StatusUnauthorized,
+			body:            `{"error":"Invalid API key or site ID."}`,
+			expectedClass:   ErrUnauthorized,
+			expectedMessage: "Invalid API key or site ID.",
+		},
+		{
+			name:            "plan without access to the API",
+			status:          http.StatusPaymentRequired,
+			body:            `{"error":"The account that owns this API key does not have access to the Stats API."}`,
+			expectedClass:   ErrPaymentRequired,
+			expectedMessage: "The account that owns this API key does not have access to the Stats API.",
+		},
+		{
+			name:          "token without permissions",
+			status:        http.StatusForbidden,
+			body:          `{"error":"forbidden"}`,
+			expectedClass: ErrForbidden,
+		},
+		{
+			name:          "unknown site",
+			status:        http.StatusNotFound,
+			body:          `{"error":"Site could not be found"}`,
+			expectedClass: ErrNotFound,
+		},
+		{
+			name:               "rate limited with retry after header",
+			status:             http.StatusTooManyRequests,
+			body:               `{"error":"Too many API requests in duration"}`,
+			retryAfter:         "30",
+			expectedClass:      ErrRateLimited,
+			expectedRetryAfter: 30 * time.Second,
+		},
+		{
+			name:          "server error without json body",
+			status:        http.StatusBadGateway,
+			body:          `<html>Bad Gateway</html>`,
+			expectedClass: ErrServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if test.retryAfter != "" {
+				w.Header().Set("Retry-After", test.retryAfter)
+			}
+			w.WriteHeader(test.status)
+			_, _ = w.Write([]byte(test.body))
+		}))
+
+		_, err := NewClientWithBaseURL("token", server.URL).Site("example.com").Details()
+		server.Close()
+
+		if !errors.Is(err, test.expectedClass) {
+			t.Fatalf("test '%s' failed: expected error to be %v, got %v", test.name, test.expectedClass, err)
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("test '%s' failed: expected error to be an *APIError, got %T", test.name, err)
+		}
+
+		if apiErr.StatusCode != test.status {
+			t.Fatalf("test '%s' failed: expected status code %d, got %d", test.name, test.status, apiErr.StatusCode)
+		}
+
+		if string(apiErr.RawBody) != test.body {
+			t.Fatalf("test '%s' failed: expected raw body %s, got %s", test.name, test.body, apiErr.RawBody)
+		}
+
+		if test.expectedMessage != "" && apiErr.Message != test.expectedMessage {
+			t.Fatalf("test '%s' failed: expected message %s, got %s", test.name, test.expectedMessage, apiErr.Message)
+		}
+
+		if apiErr.RetryAfter != test.expectedRetryAfter {
+			t.Fatalf("test '%s' failed: expected retry after %v, got %v", test.name, test.expectedRetryAfter, apiErr.RetryAfter)
+		}
+	}
+}
+
+func TestUnitParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "empty value", value: "", expected: 0},
+		{name: "number of seconds", value: strconv.Itoa(120), expected: 2 * time.Minute},
+		{name: "negative number of seconds", value: "-1", expected: 0},
+		{name: "http date in the future", value: "Sat, 01 May 2021 10:01:00 GMT", expected: time.Minute},
+		{name: "http date in the past", value: "Sat, 01 May 2021 09:00:00 GMT", expected: 0},
+		{name: "invalid value", value: "soon", expected: 0},
+	}
+
+	for _, test := range tests {
+		got := parseRetryAfter(test.value, now)
+		if got != test.expected {
+			t.Fatalf("test '%s' failed: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
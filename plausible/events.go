@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/valyala/fasthttp"
 	"regexp"
 )
 
@@ -55,32 +54,34 @@ type Revenue struct {
 	Amount   string `json:"amount"`
 }
 
-func (c *Client) acquireEventRequest(request EventRequest) (*fasthttp.Request, error) {
+func (c *Client) newEventRequest(request EventRequest) (*HTTPRequest, error) {
 	if request.UserAgent == "" {
 
 		return nil, fmt.Errorf("missing user agent information for the event request")
 	}
 
 	newBaseURL := apiVersionRegex.ReplaceAllString(c.baseURL, "/")
-	req, err := c.acquireRequestWithBaseURl(newBaseURL, "POST", "api/event", nil, nil)
+	req, err := c.newRequestWithBaseURL(newBaseURL, "POST", "api/event", nil, nil)
 	if err != nil {
 
-		return nil, fmt.Errorf("acquiring request from client for /api/event: %w", err)
+		return nil, fmt.Errorf("building request from client for /api/event: %w", err)
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", request.UserAgent)
+	// Events don't count towards the quota of the API key.
+	req.exemptFromRateLimit = true
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", request.UserAgent)
 
 	if request.IsDebuggingRequest {
-		req.Header.Add("X-Debug-Request", "true")
+		req.Header.Set("X-Debug-Request", "true")
 	}
 
 	if request.XForwardedFor != "" {
-		req.Header.Add("X-Forwarded-For", request.XForwardedFor)
+		req.Header.Set("X-Forwarded-For", request.XForwardedFor)
 	}
 
 	for header, value := range request.AdditionalHeaders {
-		req.Header.Add(header, value)
+		req.Header.Set(header, value)
 	}
 
 	body := new(bytes.Buffer)
@@ -89,6 +90,6 @@ func (c *Client) acquireEventRequest(request EventRequest) (*fasthttp.Request, e
 		return nil, fmt.Errorf("encoding request body for event request: %w", err)
 	}
 
-	req.SetBody(body.Bytes())
+	req.Body = body.Bytes()
 	return req, nil
 }
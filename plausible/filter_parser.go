@@ -0,0 +1,150 @@
+package plausible
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterParseError is the error returned by ParseFilter when the filter is not valid.
+type FilterParseError struct {
+	// Input is the filter that was being parsed.
+	Input string
+	// Position is the position, in bytes, of the input where the problem was found.
+	Position int
+	// Message describes the problem.
+	Message string
+}
+
+// Error returns a description of the error.
+func (e *FilterParseError) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Position, e.Message)
+}
+
+// filterOperatorsSyntax maps the operators of the filter syntax to filter operators.
+// Longer operators must come first, so that "!=" is not taken for "!" followed by "=".
+var filterOperatorsSyntax = []struct {
+	syntax   string
+	operator FilterOperator
+}{
+	{syntax: "==", operator: FilterIs},
+	{syntax: "!=", operator: FilterIsNot},
+	{syntax: "!~", operator: FilterContainsNot},
+	{syntax: "~", operator: FilterContains},
+}
+
+// ParseFilter parses a filter written in the filter syntax of Plausible,
+// e.g "visit:country==DE|FR;event:page!=/admin*".
+//
+// A filter is a list of clauses separated by ";". Each clause is made of a property name, an operator and one or
+// more values separated by "|", where a "|" that is part of a value is escaped with a backslash, e.g "\\|".
+// The operators are "==" (is), "!=" (is not), "~" (contains) and "!~" (contains not). Values of "==" and "!="
+// clauses with "*" are wildcard patterns, parsed with the FilterMatches and FilterMatchesNot operators.
+// Property names must be one of the property names of this package or the name of a custom property,
+// e.g "event:props:author". Spaces are not trimmed, and values that can't be sent to the API, as described
+// in the Validate method of Property, are rejected.
+//
+// An empty string is parsed as an empty filter. Errors are reported as a *FilterParseError.
+func ParseFilter(filter string) (Filter, error) {
+	f := NewFilter()
+	if filter == "" {
+		return f, nil
+	}
+
+	start := 0
+	for _, clause := range strings.Split(filter, ";") {
+		property, err := parseFilterClause(filter, clause, start)
+		if err != nil {
+			return Filter{}, err
+		}
+		f.Properties.Add(property)
+		start += len(clause) + 1
+	}
+
+	return f, nil
+}
+
+// parseFilterClause parses a clause of a filter. The clause starts at the given position of the filter.
+func parseFilterClause(filter, clause string, start int) (Property, error) {
+	fail := func(offset int, format string, args ...interface{}) (Property, error) {
+		return Property{}, &FilterParseError{Input: filter, Position: start + offset, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if clause == "" {
+		return fail(0, "empty clause")
+	}
+
+	opStart := strings.IndexAny(clause, "=!~")
+	if opStart == -1 {
+		return fail(len(clause), "missing operator in clause '%s'", clause)
+	}
+
+	name := PropertyName(clause[:opStart])
+	if name.IsEmpty() {
+		return fail(0, "missing property name")
+	}
+	if !name.IsKnown() {
+		return fail(0, "unknown property '%s'", name)
+	}
+
+	var property Property
+	var rest string
+	for _, op := range filterOperatorsSyntax {
+		if strings.HasPrefix(clause[opStart:], op.syntax) {
+			property = Property{Name: name, Operator: op.operator}
+			rest = clause[opStart+len(op.syntax):]
+			break
+		}
+	}
+	if property.Name == "" {
+		return fail(opStart, "invalid operator, expected one of '==', '!=', '~' or '!~'")
+	}
+
+	valuesStart := len(clause) - len(rest)
+	values := splitFilterValues(rest)
+	offset := valuesStart
+	for i, v := range values {
+		if v == "" {
+			return fail(offset, "empty value for property '%s'", name)
+		}
+		offset += len(v) + 1
+		values[i] = unescapeFilterValue(v)
+	}
+
+	property.Value = values[0]
+	property = property.WithOtherValues(values[1:]...)
+
+	if strings.Contains(rest, "*") {
+		switch property.Operator {
+		case FilterIs:
+			property.Operator = FilterMatches
+		case FilterIsNot:
+			property.Operator = FilterMatchesNot
+		}
+	}
+
+	if ok, invalidReason := property.Validate(); !ok {
+		return fail(valuesStart, "%s", invalidReason)
+	}
+
+	return property, nil
+}
+
+// splitFilterValues splits the values of a clause on the "|" characters that are not escaped.
+func splitFilterValues(values string) []string {
+	var res []string
+
+	start := 0
+	for i := 0; i < len(values); i++ {
+		switch values[i] {
+		case '\\':
+			if i+1 < len(values) && values[i+1] == '|' {
+				i++
+			}
+		case '|':
+			res = append(res, values[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(res, values[start:])
+}
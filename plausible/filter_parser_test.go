@@ -0,0 +1,94 @@
+package plausible
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnitParseFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         string
+		expectedFilter Filter
+	}{
+		{
+			name:           "empty filter",
+			filter:         "",
+			expectedFilter: NewFilter(),
+		},
+		{
+			name:           "filter with one property",
+			filter:         "visit:os==Windows",
+			expectedFilter: NewFilter().Where(VisitOs, FilterIs, "Windows"),
+		},
+		{
+			name:           "filter with a list of values and a wildcard",
+			filter:         "visit:country==DE|FR;event:page!=/admin*",
+			expectedFilter: NewFilter().ByVisitCountryIn("DE", "FR").Where(EventPage, FilterMatchesNot, "/admin*"),
+		},
+		{
+			name:           "filter with contains operators",
+			filter:         "event:page~/blog;visit:source!~google|bing",
+			expectedFilter: NewFilter().Where(EventPage, FilterContains, "/blog").Where(VisitSource, FilterContainsNot, "google", "bing"),
+		},
+		{
+			name:           "filter with a custom property",
+			filter:         "event:props:author==John Doe",
+			expectedFilter: NewFilter().Where(CustomPropertyName("author"), FilterIs, "John Doe"),
+		},
+		{
+			name:           "filter with escaped '|' in values",
+			filter:         `event:page==/a\|b|/c\\|d`,
+			expectedFilter: NewFilter().Where(EventPage, FilterIs, "/a|b", `/c\|d`),
+		},
+	}
+
+	for _, test := range tests {
+		got, err := ParseFilter(test.filter)
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if !reflect.DeepEqual(got, test.expectedFilter) {
+			t.Fatalf("test '%s' failed: expected filter %#v, got %#v", test.name, test.expectedFilter, got)
+		}
+
+		if got.toFilterString() != test.filter {
+			t.Fatalf("test '%s' failed: filter does not round trip, expected %s, got %s", test.name, test.filter, got.toFilterString())
+		}
+	}
+}
+
+func TestUnitParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name             string
+		filter           string
+		expectedPosition int
+	}{
+		{name: "unknown property", filter: "visit:os==Linux;visit:planet==Mars", expectedPosition: 16},
+		{name: "missing operator", filter: "visit:os", expectedPosition: 8},
+		{name: "invalid operator", filter: "visit:os=Linux", expectedPosition: 8},
+		{name: "missing property name", filter: "==Linux", expectedPosition: 0},
+		{name: "custom property without name", filter: "event:props:==a", expectedPosition: 0},
+		{name: "empty value", filter: "visit:country==DE||FR", expectedPosition: 18},
+		{name: "missing value", filter: "visit:country==", expectedPosition: 15},
+		{name: "empty clause", filter: "visit:country==DE;", expectedPosition: 18},
+		{name: "value with operator", filter: "event:page==/?a==b", expectedPosition: 12},
+		{name: "value with trailing space", filter: "visit:os==Linux ", expectedPosition: 10},
+	}
+
+	for _, test := range tests {
+		_, err := ParseFilter(test.filter)
+
+		var parseErr *FilterParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("test '%s' failed: expected a *FilterParseError, got %v", test.name, err)
+		}
+
+		if parseErr.Position != test.expectedPosition {
+			t.Fatalf("test '%s' failed: expected error at position %d, got %d (%v)",
+				test.name, test.expectedPosition, parseErr.Position, err)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package plausible
 
 // Filter represents an API query filter over properties of the stats data.
-// The filter is a logic AND over all its properties and values.
+// The filter is a logic AND over all its properties. Each property can have more than one value, in which
+// case the property matches if it matches any of its values (a logic OR), and an operator that defines
+// how the property is compared with its values, e.g. FilterIsNot or FilterContains.
 // Filters are used when making a request to the API to narrow the information returned.
 type Filter struct {
 	// Properties to filter by
@@ -20,6 +22,14 @@ func NewFilter(properties ...Property) Filter {
 	return f
 }
 
+// Where adds a filter over a property to the current filter, comparing the property with the given values
+// using the given operator. When more than one value is given, the property must match any of the values,
+// or none of them with the negated operators like FilterIsNot.
+func (f Filter) Where(name PropertyName, operator FilterOperator, value string, otherValues ...string) Filter {
+	f.Properties.Add(Property{Name: name, Value: value, Operator: operator}.WithOtherValues(otherValues...))
+	return f
+}
+
 // ByEventName adds a filter over the event name property to the current filter.
 // By default, there's a reserved event "pageviews" that Plausible provides, otherwise
 // the event must be a custom event name.
@@ -28,12 +38,37 @@ func (f Filter) ByEventName(eventName string) Filter {
 	return f
 }
 
+// ByEventNameIn adds a filter over the event name property to the current filter,
+// matching any of the given event names.
+func (f Filter) ByEventNameIn(eventName string, otherEventNames ...string) Filter {
+	return f.Where(EventName, FilterIs, eventName, otherEventNames...)
+}
+
 // ByEventPage adds a filter over the page property to the current filter.
 func (f Filter) ByEventPage(page string) Filter {
 	f.Properties.Add(Property{Name: EventPage, Value: page})
 	return f
 }
 
+// ByEventPageNot adds a filter over the page property to the current filter,
+// excluding the given page.
+func (f Filter) ByEventPageNot(page string) Filter {
+	return f.Where(EventPage, FilterIsNot, page)
+}
+
+// ByEventPageContains adds a filter over the page property to the current filter,
+// matching the pages that contain the given text, e.g "/blog".
+func (f Filter) ByEventPageContains(text string) Filter {
+	return f.Where(EventPage, FilterContains, text)
+}
+
+// ByEventPageMatches adds a filter over the page property to the current filter,
+// matching the pages that match the given wildcard pattern, e.g "/blog/**".
+// See FilterMatches for the syntax of the patterns.
+func (f Filter) ByEventPageMatches(pattern string) Filter {
+	return f.Where(EventPage, FilterMatches, pattern)
+}
+
 // ByVisitSource adds a filter over the source property to the current filter.
 // The source values are populated from the query parameter tags (utm_source, source or ref) or
 // by the Referer HTTP header.
@@ -110,6 +145,84 @@ func (f Filter) ByVisitCountry(country string) Filter {
 	return f
 }
 
+// ByVisitCountryIn adds a filter over the country property to the current filter,
+// matching any of the given countries.
+// A country value must be a string with the ISO 3166-1 alpha-2 code of the visitor country.
+func (f Filter) ByVisitCountryIn(country string, otherCountries ...string) Filter {
+	return f.Where(VisitCountry, FilterIs, country, otherCountries...)
+}
+
+// ByVisitCountryNot adds a filter over the country property to the current filter,
+// excluding the given country.
+// A country value must be a string with the ISO 3166-1 alpha-2 code of the visitor country.
+func (f Filter) ByVisitCountryNot(country string) Filter {
+	return f.Where(VisitCountry, FilterIsNot, country)
+}
+
+// ByVisitEntryPage adds a filter over the entry page property to the current filter.
+// The entry page of a visit is the first page viewed in the visit, e.g "/".
+func (f Filter) ByVisitEntryPage(page string) Filter {
+	f.Properties.Add(Property{Name: VisitEntryPage, Value: page})
+	return f
+}
+
+// ByVisitExitPage adds a filter over the exit page property to the current filter.
+// The exit page of a visit is the last page viewed in the visit, e.g "/pricing".
+func (f Filter) ByVisitExitPage(page string) Filter {
+	f.Properties.Add(Property{Name: VisitExitPage, Value: page})
+	return f
+}
+
+// ByVisitRegion adds a filter over the region property to the current filter.
+// A region value must be a string with the ISO 3166-2 code of the visitor region, e.g "PT-13".
+func (f Filter) ByVisitRegion(region string) Filter {
+	f.Properties.Add(Property{Name: VisitRegion, Value: region})
+	return f
+}
+
+// ByVisitCity adds a filter over the city property to the current filter.
+// A city value must be a string with the GeoNames ID of the visitor city, e.g "2735943".
+func (f Filter) ByVisitCity(city string) Filter {
+	f.Properties.Add(Property{Name: VisitCity, Value: city})
+	return f
+}
+
+// ByVisitUtmContent adds a filter over the utm content property to the current filter.
+// UTM Content values come from the utm_content query param.
+func (f Filter) ByVisitUtmContent(utmContent string) Filter {
+	f.Properties.Add(Property{Name: VisitUtmContent, Value: utmContent})
+	return f
+}
+
+// ByVisitUtmTerm adds a filter over the utm term property to the current filter.
+// UTM Term values come from the utm_term query param.
+func (f Filter) ByVisitUtmTerm(utmTerm string) Filter {
+	f.Properties.Add(Property{Name: VisitUtmTerm, Value: utmTerm})
+	return f
+}
+
+// ByVisitChannel adds a filter over the acquisition channel property to the current filter.
+// Examples of channel values are "Organic Search", "Direct" and "Referral".
+func (f Filter) ByVisitChannel(channel string) Filter {
+	f.Properties.Add(Property{Name: VisitChannel, Value: channel})
+	return f
+}
+
+// ByEventGoal adds a filter over the goal property to the current filter.
+// A goal value is the name of a custom event goal, e.g "Signup", or "Visit " followed by the path of
+// a pageview goal, e.g "Visit /register".
+func (f Filter) ByEventGoal(goal string) Filter {
+	f.Properties.Add(Property{Name: EventGoal, Value: goal})
+	return f
+}
+
+// ByEventHostname adds a filter over the hostname property to the current filter.
+// The hostname is the host where the event happened, e.g "blog.example.com".
+func (f Filter) ByEventHostname(hostname string) Filter {
+	f.Properties.Add(Property{Name: EventHostname, Value: hostname})
+	return f
+}
+
 // ByCustomProperty adds a filter over a custom property to the current filter.
 func (f Filter) ByCustomProperty(propertyName string, value string) Filter {
 	f.Properties.Add(Property{Name: CustomPropertyName(propertyName), Value: value})
@@ -148,6 +261,19 @@ func (f Filter) toQueryArgs() QueryArgs {
 	return qArgs
 }
 
+// Validate tells whether the filter is valid or not.
+// If the filter is not valid, a string explaining why the filter is not valid will be returned.
+// Check the Validate method of Property to know which values are valid.
+func (f Filter) Validate() (ok bool, invalidReason string) {
+	for _, p := range f.Properties {
+		if ok, invalidReason := p.Validate(); !ok {
+			return false, invalidReason
+		}
+	}
+
+	return true, ""
+}
+
 // IsEmpty tells if the filter has no properties
 func (f Filter) IsEmpty() bool {
 	return f.Properties.Count() == 0
@@ -180,6 +180,76 @@ func TestUnitFilterString(t *testing.T) {
 			expectedPropertyCount: 2,
 			isEmpty:               false,
 		},
+		{
+			name:                 "filter with event names in a list",
+			filter:               NewFilter().ByEventNameIn("Signup", "Login"),
+			expectedFilterString: "event:name==Signup|Login",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "event:name==Signup|Login"},
+			},
+			expectedPropertyCount: 1,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter excluding a page",
+			filter:               NewFilter().ByEventPageNot("/admin"),
+			expectedFilterString: "event:page!=/admin",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "event:page!=/admin"},
+			},
+			expectedPropertyCount: 1,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter with pages containing a text",
+			filter:               NewFilter().ByEventPageContains("/blog"),
+			expectedFilterString: "event:page~/blog",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "event:page~/blog"},
+			},
+			expectedPropertyCount: 1,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter with pages matching a pattern",
+			filter:               NewFilter().ByEventPageMatches("/blog/**"),
+			expectedFilterString: "event:page==/blog/**",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "event:page==/blog/**"},
+			},
+			expectedPropertyCount: 1,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter with countries in a list and excluding a country",
+			filter:               NewFilter().ByVisitCountryIn("DE", "FR").ByVisitCountryNot("PT"),
+			expectedFilterString: "visit:country==DE|FR;visit:country!=PT",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "visit:country==DE|FR;visit:country!=PT"},
+			},
+			expectedPropertyCount: 2,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter with a custom property not containing any of the values",
+			filter:               NewFilter().Where(CustomPropertyName("plan"), FilterContainsNot, "trial", "free"),
+			expectedFilterString: "event:props:plan!~trial|free",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "event:props:plan!~trial|free"},
+			},
+			expectedPropertyCount: 1,
+			isEmpty:               false,
+		},
+		{
+			name:                 "filter with entry page, city and goal properties",
+			filter:               NewFilter().ByVisitEntryPage("/").ByVisitCity("2735943").ByEventGoal("Signup"),
+			expectedFilterString: "visit:entry_page==/;visit:city==2735943;event:goal==Signup",
+			expectedQueryArgs: QueryArgs{
+				QueryArg{Name: "filters", Value: "visit:entry_page==/;visit:city==2735943;event:goal==Signup"},
+			},
+			expectedPropertyCount: 3,
+			isEmpty:               false,
+		},
 		{
 			name:                 "filter with one custom property",
 			filter:               NewFilter().ByCustomProperty("myproperty", "click"),
@@ -0,0 +1,113 @@
+package plausible
+
+import (
+	"strings"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+// GoalType is the type of a goal, which tells what completes the goal.
+type GoalType string
+
+const (
+	// EventGoalType is the type of goals completed by custom events with a given name.
+	EventGoalType = GoalType("event")
+	// PageGoalType is the type of goals completed by pageviews of a given page path.
+	PageGoalType = GoalType("page")
+)
+
+// CreateGoalRequest represents a request to create a goal for a site.
+// Event goals must have an event name and page goals must have a page path.
+type CreateGoalRequest struct {
+	// GoalType is the type of the goal.
+	// This field is mandatory.
+	GoalType GoalType
+	// EventName is the name of the custom event that completes the goal, for event goals.
+	EventName string
+	// PagePath is the path of the page that completes the goal, for page goals, e.g "/register".
+	// Wildcards can be used to match more than one page, e.g "/blog/**".
+	PagePath string
+}
+
+// EventGoalRequest returns a request to create a goal completed by custom events with the given name.
+func EventGoalRequest(eventName string) CreateGoalRequest {
+	return CreateGoalRequest{GoalType: EventGoalType, EventName: eventName}
+}
+
+// PageGoalRequest returns a request to create a goal completed by pageviews of the given page path.
+func PageGoalRequest(pagePath string) CreateGoalRequest {
+	return CreateGoalRequest{GoalType: PageGoalType, PagePath: pagePath}
+}
+
+// Validate tells whether the request is valid or not.
+// If the request is not valid, a string explaining why the request is not valid will be returned.
+func (cgr *CreateGoalRequest) Validate() (bool, string) {
+	switch cgr.GoalType {
+	case EventGoalType:
+		if cgr.EventName == "" {
+			return false, "an event name must be specified for an event goal"
+		}
+		if cgr.PagePath != "" {
+			return false, "a page path can't be specified for an event goal"
+		}
+	case PageGoalType:
+		if cgr.PagePath == "" {
+			return false, "a page path must be specified for a page goal"
+		}
+		if !strings.HasPrefix(cgr.PagePath, "/") {
+			return false, "the page path of a page goal must start with '/'"
+		}
+		if cgr.EventName != "" {
+			return false, "an event name can't be specified for a page goal"
+		}
+	case "":
+		return false, "a goal type must be specified in a request to create a goal"
+	default:
+		return false, "unknown goal type '" + string(cgr.GoalType) + "', expected 'event' or 'page'"
+	}
+
+	return true, ""
+}
+
+func (cgr *CreateGoalRequest) toFormArgs(siteID string) QueryArgs {
+	res := QueryArgs{
+		{Name: "site_id", Value: siteID},
+		{Name: "goal_type", Value: string(cgr.GoalType)},
+	}
+
+	if cgr.GoalType == EventGoalType {
+		res = append(res, QueryArg{Name: "event_name", Value: cgr.EventName})
+	} else {
+		res = append(res, QueryArg{Name: "page_path", Value: cgr.PagePath})
+	}
+
+	return res
+}
+
+// GoalResult contains the details of a goal of a site.
+type GoalResult struct {
+	// ID of the goal.
+	ID int `json:"id"`
+	// DisplayName is the name of the goal shown in the dashboard.
+	DisplayName string `json:"display_name"`
+	// GoalType is the type of the goal.
+	GoalType GoalType `json:"goal_type"`
+	// EventName is the name of the custom event that completes the goal. It's empty for page goals.
+	EventName string `json:"event_name"`
+	// PagePath is the path of the page that completes the goal. It's empty for event goals.
+	PagePath string `json:"page_path"`
+}
+
+// ListGoalsResult is the result of a request to list the goals of a site.
+type ListGoalsResult struct {
+	// Goals is the list of goals in a response
+	Goals []GoalResult `json:"goals"`
+	// Meta is the pagination meta information of a page
+	Meta pagination.Meta `json:"meta"`
+}
+
+// DeleteGoalResult is the result of a request to delete a goal.
+type DeleteGoalResult struct {
+	// Deleted tells whether the goal was deleted.
+	Deleted bool `json:"deleted"`
+}
@@ -0,0 +1,156 @@
+package plausible
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+func TestUnitValidateCreateGoalRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CreateGoalRequest
+		isValid bool
+	}{
+		{
+			name:    "valid event goal request that should succeed",
+			request: EventGoalRequest("Signup"),
+			isValid: true,
+		},
+		{
+			name:    "valid page goal request that should succeed",
+			request: PageGoalRequest("/register"),
+			isValid: true,
+		},
+		{
+			name:    "invalid event goal request without event name that should fail",
+			request: EventGoalRequest(""),
+			isValid: false,
+		},
+		{
+			name:    "invalid page goal request with a relative path that should fail",
+			request: PageGoalRequest("register"),
+			isValid: false,
+		},
+		{
+			name:    "invalid event goal request with a page path that should fail",
+			request: CreateGoalRequest{GoalType: EventGoalType, EventName: "Signup", PagePath: "/register"},
+			isValid: false,
+		},
+		{
+			name:    "invalid goal request without type that should fail",
+			request: CreateGoalRequest{EventName: "Signup"},
+			isValid: false,
+		},
+		{
+			name:    "invalid goal request with an unknown type that should fail",
+			request: CreateGoalRequest{GoalType: GoalType("revenue"), EventName: "Signup"},
+			isValid: false,
+		},
+	}
+
+	for _, test := range tests {
+		valid, _ := test.request.Validate()
+		if valid && !test.isValid {
+			t.Fatalf("test '%s' is valid, but was expected to fail", test.name)
+		}
+		if !valid && test.isValid {
+			t.Fatalf("test '%s' is invalid, but was expected to succeed", test.name)
+		}
+	}
+}
+
+func TestUnitToFormArgsCreateGoalRequest(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          CreateGoalRequest
+		expectedFormArgs QueryArgs
+	}{
+		{
+			name:    "event goal request",
+			request: EventGoalRequest("Signup"),
+			expectedFormArgs: QueryArgs{
+				QueryArg{Name: "site_id", Value: "example.com"},
+				QueryArg{Name: "goal_type", Value: "event"},
+				QueryArg{Name: "event_name", Value: "Signup"},
+			},
+		},
+		{
+			name:    "page goal request",
+			request: PageGoalRequest("/register"),
+			expectedFormArgs: QueryArgs{
+				QueryArg{Name: "site_id", Value: "example.com"},
+				QueryArg{Name: "goal_type", Value: "page"},
+				QueryArg{Name: "page_path", Value: "/register"},
+			},
+		},
+	}
+	for _, test := range tests {
+		actualFormArgs := test.request.toFormArgs("example.com")
+		equal := actualFormArgs.equalTo(test.expectedFormArgs)
+		if !equal {
+			t.Fatalf("test '%s' failed: non-equal form args %v and %v",
+				test.name, test.expectedFormArgs, actualFormArgs)
+		}
+	}
+}
+
+func TestUnitGoalRequests(t *testing.T) {
+	var method, path, rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, rawQuery = r.Method, r.URL.Path, r.URL.RawQuery
+
+		switch r.Method {
+		case "PUT":
+			_, _ = w.Write([]byte(`{"id":1,"display_name":"Visit /register","goal_type":"page",` +
+				`"event_name":null,"page_path":"/register"}`))
+		case "GET":
+			_, _ = w.Write([]byte(`{"goals":[{"id":2,"display_name":"Signup","goal_type":"event",` +
+				`"event_name":"Signup","page_path":null}],"meta":{"after":"2","limit":1}}`))
+		case "DELETE":
+			_, _ = w.Write([]byte(`{"deleted":true}`))
+		}
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	goal, err := site.CreateGoal(PageGoalRequest("/register"))
+	if err != nil {
+		t.Fatalf("unexpected error creating the goal: %v", err)
+	}
+	if method != "PUT" || path != "/sites/goals" {
+		t.Fatalf("expected a PUT request to /sites/goals, got %s %s", method, path)
+	}
+	if goal.ID != 1 || goal.GoalType != PageGoalType || goal.PagePath != "/register" || goal.EventName != "" {
+		t.Fatalf("unexpected created goal %+v", goal)
+	}
+
+	if _, err = site.CreateGoal(CreateGoalRequest{}); err == nil {
+		t.Fatalf("expected an error creating an invalid goal")
+	}
+
+	goals, err := site.ListGoals(pagination.Limit(1))
+	if err != nil {
+		t.Fatalf("unexpected error listing the goals: %v", err)
+	}
+	if method != "GET" || path != "/sites/goals" || rawQuery != "limit=1&site_id=example.com" {
+		t.Fatalf("expected a GET request to /sites/goals?limit=1&site_id=example.com, got %s %s?%s", method, path, rawQuery)
+	}
+	if len(goals.Goals) != 1 || goals.Goals[0].EventName != "Signup" || !goals.Meta.HasNext() {
+		t.Fatalf("unexpected list of goals %+v", goals)
+	}
+
+	deleted, err := site.DeleteGoal(2)
+	if err != nil {
+		t.Fatalf("unexpected error deleting the goal: %v", err)
+	}
+	if method != "DELETE" || path != "/sites/goals/2" {
+		t.Fatalf("expected a DELETE request to /sites/goals/2, got %s %s", method, path)
+	}
+	if !deleted.Deleted {
+		t.Fatalf("expected the goal to be deleted")
+	}
+}
@@ -0,0 +1,76 @@
+package plausible
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+// GuestRole is the role of a guest of a site, which tells what the guest can do with the site.
+type GuestRole string
+
+const (
+	// GuestViewer is the role of guests that can only view the stats of the site.
+	GuestViewer = GuestRole("viewer")
+	// GuestEditor is the role of guests that can view the stats and change the settings of the site.
+	GuestEditor = GuestRole("editor")
+)
+
+// IsValid tells whether the role is one of the roles known by the API.
+func (r GuestRole) IsValid() bool {
+	return r == GuestViewer || r == GuestEditor
+}
+
+// GuestResult contains the details of a guest of a site.
+type GuestResult struct {
+	// Email of the guest.
+	Email string `json:"email"`
+	// Role of the guest.
+	Role GuestRole `json:"role"`
+	// Status of the guest, "invited" while the invitation is not accepted and "accepted" after.
+	Status string `json:"status"`
+}
+
+// ListGuestsResult is the result of a request to list the guests of a site.
+type ListGuestsResult struct {
+	// Guests is the list of guests in a response
+	Guests []GuestResult `json:"guests"`
+	// Meta is the pagination meta information of a page
+	Meta pagination.Meta `json:"meta"`
+}
+
+// RemoveGuestResult is the result of a request to remove a guest from a site.
+type RemoveGuestResult struct {
+	// Deleted tells whether the guest was removed.
+	Deleted bool `json:"deleted"`
+}
+
+// validateGuestEmail tells whether an email of a guest is valid.
+// If the email is not valid, a string explaining why will be returned.
+func validateGuestEmail(email string) (bool, string) {
+	if email == "" {
+		return false, "an email must be specified for a guest"
+	}
+	if !strings.Contains(email, "@") {
+		return false, "invalid guest email '" + email + "'"
+	}
+	return true, ""
+}
+
+// validateGuestInvitation tells whether an invitation of a guest is valid.
+// If the invitation is not valid, a string explaining why will be returned.
+func validateGuestInvitation(email string, role GuestRole) (bool, string) {
+	if ok, invalidReason := validateGuestEmail(email); !ok {
+		return false, invalidReason
+	}
+	if !role.IsValid() {
+		return false, "unknown guest role '" + string(role) + "', expected 'viewer' or 'editor'"
+	}
+	return true, ""
+}
+
+// guestPath returns the endpoint of a guest of a site.
+func guestPath(email string) string {
+	return "sites/guests/" + url.PathEscape(email)
+}
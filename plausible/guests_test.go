@@ -0,0 +1,122 @@
+package plausible
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+func TestUnitValidateGuestInvitation(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		role    GuestRole
+		isValid bool
+	}{
+		{
+			name:    "valid viewer invitation that should succeed",
+			email:   "alice@example.com",
+			role:    GuestViewer,
+			isValid: true,
+		},
+		{
+			name:    "valid editor invitation that should succeed",
+			email:   "alice@example.com",
+			role:    GuestEditor,
+			isValid: true,
+		},
+		{
+			name:    "invalid invitation without email that should fail",
+			role:    GuestViewer,
+			isValid: false,
+		},
+		{
+			name:    "invalid invitation with a malformed email that should fail",
+			email:   "alice",
+			role:    GuestViewer,
+			isValid: false,
+		},
+		{
+			name:    "invalid invitation without role that should fail",
+			email:   "alice@example.com",
+			isValid: false,
+		},
+		{
+			name:    "invalid invitation with an unknown role that should fail",
+			email:   "alice@example.com",
+			role:    GuestRole("owner"),
+			isValid: false,
+		},
+	}
+
+	for _, test := range tests {
+		valid, _ := validateGuestInvitation(test.email, test.role)
+		if valid && !test.isValid {
+			t.Fatalf("test '%s' is valid, but was expected to fail", test.name)
+		}
+		if !valid && test.isValid {
+			t.Fatalf("test '%s' is invalid, but was expected to succeed", test.name)
+		}
+	}
+}
+
+func TestUnitGuestRequests(t *testing.T) {
+	var method, path, rawQuery, email, role string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, rawQuery = r.Method, r.URL.Path, r.URL.RawQuery
+		email, role = r.FormValue("email"), r.FormValue("role")
+
+		switch r.Method {
+		case "PUT":
+			_, _ = w.Write([]byte(`{"email":"alice@example.com","role":"editor","status":"invited"}`))
+		case "GET":
+			_, _ = w.Write([]byte(`{"guests":[{"email":"bob@example.com","role":"viewer","status":"accepted"}],` +
+				`"meta":{"before":"x","limit":1}}`))
+		case "DELETE":
+			_, _ = w.Write([]byte(`{"deleted":true}`))
+		}
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	guest, err := site.InviteGuest("alice@example.com", GuestEditor)
+	if err != nil {
+		t.Fatalf("unexpected error inviting the guest: %v", err)
+	}
+	if method != "PUT" || path != "/sites/guests" || email != "alice@example.com" || role != "editor" {
+		t.Fatalf("expected a PUT request to /sites/guests with the email and role, got %s %s with '%s' and '%s'",
+			method, path, email, role)
+	}
+	if guest.Role != GuestEditor || guest.Status != "invited" {
+		t.Fatalf("unexpected invited guest %+v", guest)
+	}
+
+	if _, err = site.InviteGuest("alice@example.com", GuestRole("owner")); err == nil {
+		t.Fatalf("expected an error inviting a guest with an unknown role")
+	}
+
+	guests, err := site.ListGuests(pagination.Limit(1))
+	if err != nil {
+		t.Fatalf("unexpected error listing the guests: %v", err)
+	}
+	if method != "GET" || path != "/sites/guests" || rawQuery != "limit=1&site_id=example.com" {
+		t.Fatalf("expected a GET request to /sites/guests?limit=1&site_id=example.com, got %s %s?%s", method, path, rawQuery)
+	}
+	if len(guests.Guests) != 1 || guests.Guests[0].Role != GuestViewer || !guests.Meta.HasPrevious() {
+		t.Fatalf("unexpected list of guests %+v", guests)
+	}
+
+	removed, err := site.RemoveGuest("bob@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error removing the guest: %v", err)
+	}
+	if method != "DELETE" || path != "/sites/guests/bob@example.com" {
+		t.Fatalf("expected a DELETE request to /sites/guests/bob@example.com, got %s %s", method, path)
+	}
+	if !removed.Deleted {
+		t.Fatalf("expected the guest to be removed")
+	}
+}
@@ -19,6 +19,29 @@ const (
 	Visits = Metric("visits")
 	// Events represents the number of events (pageviews + custom events) metric
 	Events = Metric("events")
+	// ViewsPerVisit represents the number of page views per visit metric
+	ViewsPerVisit = Metric("views_per_visit")
+	// ConversionRate represents the conversion rate metric, the percentage of visitors that completed a goal.
+	// This metric requires a filter or a breakdown by goal.
+	ConversionRate = Metric("conversion_rate")
+	// GroupConversionRate represents the conversion rate metric of each group of a breakdown by a custom property.
+	// This metric is only available in breakdown queries.
+	GroupConversionRate = Metric("group_conversion_rate")
+	// TimeOnPage represents the time on page metric, in seconds.
+	// This metric requires a filter or a breakdown by page.
+	TimeOnPage = Metric("time_on_page")
+	// Percentage represents the percentage of visitors metric of each group of a breakdown.
+	// This metric is only available in breakdown queries.
+	Percentage = Metric("percentage")
+	// ScrollDepth represents the scroll depth metric, the percentage of the page the visitors scrolled.
+	// This metric requires a filter or a breakdown by page.
+	ScrollDepth = Metric("scroll_depth")
+	// TotalRevenue represents the total revenue metric of revenue goals.
+	// This metric requires a filter or a breakdown by goal.
+	TotalRevenue = Metric("total_revenue")
+	// AverageRevenue represents the average revenue metric of revenue goals.
+	// This metric requires a filter or a breakdown by goal.
+	AverageRevenue = Metric("average_revenue")
 )
 
 // AllMetrics is an utility function that returns all the metrics.
@@ -1,6 +1,9 @@
 package plausible
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // PropertyName represents the name of a property. Check the constants of this package to see a list of PropertyName
 // values ready to use.
@@ -34,8 +37,65 @@ const (
 	VisitOsVersion = PropertyName("visit:os_version")
 	// VisitCountry is the name of the country property of a visit
 	VisitCountry = PropertyName("visit:country")
+	// VisitEntryPage is the name of the entry page property of a visit
+	VisitEntryPage = PropertyName("visit:entry_page")
+	// VisitExitPage is the name of the exit page property of a visit
+	VisitExitPage = PropertyName("visit:exit_page")
+	// VisitRegion is the name of the region property of a visit
+	VisitRegion = PropertyName("visit:region")
+	// VisitCity is the name of the city property of a visit
+	VisitCity = PropertyName("visit:city")
+	// VisitUtmContent is the name of the utm content property of a visit
+	VisitUtmContent = PropertyName("visit:utm_content")
+	// VisitUtmTerm is the name of the utm term property of a visit
+	VisitUtmTerm = PropertyName("visit:utm_term")
+	// VisitChannel is the name of the acquisition channel property of a visit
+	VisitChannel = PropertyName("visit:channel")
+	// EventGoal is the name of the goal property of an event
+	EventGoal = PropertyName("event:goal")
+	// EventHostname is the name of the hostname property of an event
+	EventHostname = PropertyName("event:hostname")
 )
 
+// knownPropertyNames contains the names of all the properties declared in this package.
+var knownPropertyNames = map[PropertyName]bool{
+	EventName:           true,
+	EventPage:           true,
+	VisitSource:         true,
+	VisitReferrer:       true,
+	VisitUtmMedium:      true,
+	VisitUtmSource:      true,
+	VisitUtmCampaign:    true,
+	VisitDevice:         true,
+	VisitBrowser:        true,
+	VisitBrowserVersion: true,
+	VisitOs:             true,
+	VisitOsVersion:      true,
+	VisitCountry:        true,
+	VisitEntryPage:      true,
+	VisitExitPage:       true,
+	VisitRegion:         true,
+	VisitCity:           true,
+	VisitUtmContent:     true,
+	VisitUtmTerm:        true,
+	VisitChannel:        true,
+	EventGoal:           true,
+	EventHostname:       true,
+}
+
+// customPropertyPrefix is the prefix of the names of custom properties.
+const customPropertyPrefix = "event:props:"
+
+// IsKnown tells whether the property name is one of the property names declared in this package
+// or the name of a custom property.
+func (pn *PropertyName) IsKnown() bool {
+	name := string(*pn)
+	if strings.HasPrefix(name, customPropertyPrefix) {
+		return len(name) > len(customPropertyPrefix)
+	}
+	return knownPropertyNames[*pn]
+}
+
 // IsEmpty tells whether the name of the property is empty
 func (pn *PropertyName) IsEmpty() bool {
 	return string(*pn) == ""
@@ -47,17 +107,156 @@ func (pn *PropertyName) toQueryArgs() QueryArgs {
 	}
 }
 
+// FilterOperator represents the operator used to compare a property with its values in a filter.
+// Check the constants of this package to see a list of FilterOperator values ready to use.
+type FilterOperator string
+
+// FilterOperator values:
+const (
+	// FilterIs matches properties equal to any of the values.
+	// Like in the v1 API, values with "*" are wildcard patterns, see FilterMatches.
+	FilterIs = FilterOperator("is")
+	// FilterIsNot matches properties different from all the values.
+	// Like in the v1 API, values with "*" are wildcard patterns, see FilterMatches.
+	FilterIsNot = FilterOperator("is_not")
+	// FilterContains matches properties that contain any of the values.
+	FilterContains = FilterOperator("contains")
+	// FilterContainsNot matches properties that contain none of the values.
+	FilterContainsNot = FilterOperator("contains_not")
+	// FilterMatches matches properties that match any of the values, which are wildcard patterns.
+	// In a pattern, "*" matches any sequence of characters except "/", and "**" matches any sequence of characters,
+	// e.g "/blog/**" matches all the pages under "/blog/".
+	FilterMatches = FilterOperator("matches")
+	// FilterMatchesNot matches properties that match none of the values, which are wildcard patterns.
+	// See FilterMatches for the syntax of the patterns.
+	FilterMatchesNot = FilterOperator("matches_not")
+)
+
+// v1Operator returns the operator of the filter syntax of the v1 API.
+// In the v1 API, wildcard patterns are given with the equality operators.
+func (fo FilterOperator) v1Operator() string {
+	switch fo {
+	case FilterIsNot, FilterMatchesNot:
+		return "!="
+	case FilterContains:
+		return "~"
+	case FilterContainsNot:
+		return "!~"
+	}
+
+	return "=="
+}
+
+// orDefault returns the operator, or FilterIs if the operator is empty.
+func (fo FilterOperator) orDefault() FilterOperator {
+	if fo == "" {
+		return FilterIs
+	}
+	return fo
+}
+
 // Property represents a Plausible property, consisting of a name and value.
 // Properties are used when building filters for queries
 type Property struct {
 	// Name is the name of the property
 	Name PropertyName
-	// Value is the value associated with the property
+	// Value is the value associated with the property.
+	// To filter by more than one value of the property, use WithOtherValues.
 	Value string
+	// Operator is the operator used to compare the property with its values when filtering.
+	// This field is optional and defaults to FilterIs.
+	Operator FilterOperator
+
+	// otherValues are the values given to WithOtherValues. They are behind a pointer to keep Property comparable.
+	otherValues *[]string
+}
+
+// WithOtherValues returns a copy of the property with additional values. A filter over the property
+// matches any of the values, or none of them with the negated operators like FilterIsNot.
+//
+// Properties with other values are only equal with == if they are copies of each other,
+// use Values to compare their values.
+func (p Property) WithOtherValues(values ...string) Property {
+	others := append(p.OtherValues(), values...)
+	if len(others) == 0 {
+		p.otherValues = nil
+	} else {
+		p.otherValues = &others
+	}
+	return p
+}
+
+// OtherValues returns the additional values of the property given to WithOtherValues.
+func (p *Property) OtherValues() []string {
+	if p.otherValues == nil {
+		return nil
+	}
+	return append([]string(nil), *p.otherValues...)
+}
+
+// Values returns all the values of the property, i.e. Value followed by OtherValues.
+func (p *Property) Values() []string {
+	return append([]string{p.Value}, p.OtherValues()...)
+}
+
+// Validate tells whether the property can be used in a filter.
+// If the property can't be used in a filter, a string explaining why will be returned.
+//
+// Values are sent to the API in the filter syntax, where a few characters have a special meaning. The "|" character
+// is escaped with a backslash, but values with ";", "==" or "!=", values with leading or trailing spaces, empty values
+// and values ending with a backslash followed by another value can't be represented in this syntax.
+func (p *Property) Validate() (ok bool, invalidReason string) {
+	if p.Name.IsEmpty() {
+		return false, "a property in a filter must have a name"
+	}
+
+	switch p.Operator.orDefault() {
+	case FilterIs, FilterIsNot, FilterContains, FilterContainsNot, FilterMatches, FilterMatchesNot:
+	default:
+		return false, fmt.Sprintf("invalid operator '%s' for property '%s'", p.Operator, p.Name)
+	}
+
+	values := p.Values()
+	for i, v := range values {
+		invalid := ""
+		switch {
+		case v == "":
+			invalid = "values can't be empty"
+		case strings.TrimSpace(v) != v:
+			invalid = "values can't have leading or trailing spaces"
+		case strings.Contains(v, ";"):
+			invalid = "values can't contain ';'"
+		case strings.Contains(v, "==") || strings.Contains(v, "!="):
+			invalid = "values can't contain '==' or '!='"
+		case strings.HasSuffix(v, `\`) && i != len(values)-1:
+			invalid = "values followed by other values can't end with '\\'"
+		}
+
+		if invalid != "" {
+			return false, fmt.Sprintf("invalid value '%s' for property '%s': %s", v, p.Name, invalid)
+		}
+	}
+
+	return true, ""
 }
 
 func (p *Property) toFilterString() string {
-	return fmt.Sprintf("%s==%s", p.Name, p.Value)
+	values := p.Values()
+	for i, v := range values {
+		values[i] = escapeFilterValue(v)
+	}
+
+	return fmt.Sprintf("%s%s%s", p.Name, p.Operator.orDefault().v1Operator(), strings.Join(values, "|"))
+}
+
+// escapeFilterValue escapes the characters of a value with a special meaning in the filter syntax.
+func escapeFilterValue(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// unescapeFilterValue reverts escapeFilterValue.
+func unescapeFilterValue(value string) string {
+	return strings.ReplaceAll(value, `\|`, "|")
 }
 
 // CustomPropertyName makes a PropertyName for a custom property with a given name.
@@ -65,7 +264,7 @@ func (p *Property) toFilterString() string {
 // Also check the function CustomProperty for an easy way to create a property with
 // a value and a custom name.
 func CustomPropertyName(propertyName string) PropertyName {
-	return PropertyName("event:props:" + propertyName)
+	return PropertyName(customPropertyPrefix + propertyName)
 }
 
 // CustomProperty makes a Property out of a custom name and value for that property.
@@ -1,6 +1,10 @@
 package plausible
 
-import "testing"
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
 
 func TestUnitIndividualProperties(t *testing.T) {
 	tests := []struct {
@@ -17,6 +21,13 @@ func TestUnitIndividualProperties(t *testing.T) {
 			expectedValue:        "profile",
 			expectedFilterString: "event:props:myproperty==profile",
 		},
+		{
+			name:                 "property with a '|' in the value",
+			property:             Property{Name: EventPage, Value: "/a|b"}.WithOtherValues("/c"),
+			expectedName:         EventPage,
+			expectedValue:        "/a|b",
+			expectedFilterString: `event:page==/a\|b|/c`,
+		},
 	}
 
 	for _, test := range tests {
@@ -39,3 +50,142 @@ func TestUnitIndividualProperties(t *testing.T) {
 
 	}
 }
+
+func TestUnitPropertyQueryFilter(t *testing.T) {
+	tests := []struct {
+		name                string
+		property            Property
+		expectedQueryFilter string
+	}{
+		{
+			name:                "property without operator",
+			property:            Property{Name: VisitCountry, Value: "DE"},
+			expectedQueryFilter: `["is","visit:country",["DE"]]`,
+		},
+		{
+			name:                "property with is not operator and several values",
+			property:            Property{Name: VisitCountry, Value: "DE", Operator: FilterIsNot}.WithOtherValues("FR"),
+			expectedQueryFilter: `["is_not","visit:country",["DE","FR"]]`,
+		},
+		{
+			name:                "property with contains operator",
+			property:            Property{Name: EventPage, Value: "/blog", Operator: FilterContains},
+			expectedQueryFilter: `["contains","event:page",["/blog"]]`,
+		},
+		{
+			name:                "property with matches operator and a wildcard pattern",
+			property:            Property{Name: EventPage, Value: "/blog/*/comments.html", Operator: FilterMatches},
+			expectedQueryFilter: `["matches","event:page",["^/blog/[^/]*/comments\\.html$"]]`,
+		},
+		{
+			name:                "property with matches not operator and a double wildcard pattern",
+			property:            Property{Name: EventPage, Value: "/admin/**", Operator: FilterMatchesNot},
+			expectedQueryFilter: `["matches_not","event:page",["^/admin/.*$"]]`,
+		},
+		{
+			name:                "property without operator and a wildcard pattern",
+			property:            Property{Name: EventPage, Value: "/blog/*"},
+			expectedQueryFilter: `["matches","event:page",["^/blog/[^/]*$"]]`,
+		},
+		{
+			name:                "property with is not operator and a wildcard pattern among other values",
+			property:            Property{Name: EventPage, Value: "/", Operator: FilterIsNot}.WithOtherValues("/admin/**"),
+			expectedQueryFilter: `["matches_not","event:page",["^/$","^/admin/.*$"]]`,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := json.Marshal(test.property.toQueryFilter())
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if string(got) != test.expectedQueryFilter {
+			t.Fatalf("test '%s' failed: expected query filter %s, got %s", test.name, test.expectedQueryFilter, got)
+		}
+	}
+}
+
+func TestUnitValidateProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		property Property
+		isValid  bool
+	}{
+		{
+			name:     "valid property",
+			property: Property{Name: VisitCountry, Value: "DE"}.WithOtherValues("FR"),
+			isValid:  true,
+		},
+		{
+			name:     "valid property with a '|' and a trailing backslash in the last value",
+			property: Property{Name: EventPage, Value: "/a|b"}.WithOtherValues(`/c\`),
+			isValid:  true,
+		},
+		{
+			name:     "invalid property due to missing name",
+			property: Property{Value: "DE"},
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to unknown operator",
+			property: Property{Name: VisitCountry, Value: "DE", Operator: FilterOperator("like")},
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to empty value",
+			property: Property{Name: VisitCountry, Value: "DE"}.WithOtherValues(""),
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to trailing space",
+			property: Property{Name: VisitOs, Value: "Linux "},
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to ';' in value",
+			property: Property{Name: EventPage, Value: "/a;b"},
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to '==' in value",
+			property: Property{Name: EventPage, Value: "/?a==b"},
+			isValid:  false,
+		},
+		{
+			name:     "invalid property due to a backslash at the end of a value followed by another value",
+			property: Property{Name: EventPage, Value: `/a\`}.WithOtherValues("/b"),
+			isValid:  false,
+		},
+	}
+
+	for _, test := range tests {
+		valid, reason := test.property.Validate()
+		if valid && !test.isValid {
+			t.Fatalf("test '%s' is valid, but was expected to fail", test.name)
+		}
+		if !valid && test.isValid {
+			t.Fatalf("test '%s' is invalid, but was expected to succeed: %s", test.name, reason)
+		}
+	}
+}
+
+func TestUnitPropertyComparable(t *testing.T) {
+	seen := map[Property]bool{}
+	seen[Property{Name: VisitCountry, Value: "DE"}] = true
+
+	if !seen[Property{Name: VisitCountry, Value: "DE"}] {
+		t.Fatalf("expected equal properties to be the same map key")
+	}
+
+	property := Property{Name: VisitCountry, Value: "DE"}.WithOtherValues("FR")
+	other := property.WithOtherValues("PT")
+
+	if copied := property; copied != property || property == other {
+		t.Fatalf("expected copies of a property to be equal and properties with other values to be different")
+	}
+
+	if strings.Join(property.Values(), ",") != "DE,FR" || strings.Join(other.Values(), ",") != "DE,FR,PT" {
+		t.Fatalf("expected other values to not be shared between copies, got %v and %v", property.Values(), other.Values())
+	}
+}
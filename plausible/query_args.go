@@ -1,8 +1,11 @@
 package plausible
 
 import (
-	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+	"net/url"
 	"strconv"
+	"strings"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
 )
 
 // QueryArgs represents a list of query arguments.
@@ -41,6 +44,15 @@ func (qa QueryArgs) equalTo(other QueryArgs) bool {
 	return true
 }
 
+// encode encodes the query arguments in the URL query string format, keeping the order of the arguments.
+func (qa QueryArgs) encode() string {
+	parts := make([]string, 0, qa.Count())
+	for _, q := range qa {
+		parts = append(parts, url.QueryEscape(q.Name)+"="+url.QueryEscape(q.Value))
+	}
+	return strings.Join(parts, "&")
+}
+
 // Count returns the number of query arguments in the list
 func (qa *QueryArgs) Count() int {
 	return len(*qa)
@@ -0,0 +1,504 @@
+package plausible
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Time dimensions can be used as dimensions of a QueryV2 to group the results by periods of time.
+const (
+	// TimeDimension groups the results by the period of time that best fits the date range of the query.
+	TimeDimension = PropertyName("time")
+	// TimeHourDimension groups the results by hour.
+	TimeHourDimension = PropertyName("time:hour")
+	// TimeDayDimension groups the results by day.
+	TimeDayDimension = PropertyName("time:day")
+	// TimeWeekDimension groups the results by week.
+	TimeWeekDimension = PropertyName("time:week")
+	// TimeMonthDimension groups the results by month.
+	TimeMonthDimension = PropertyName("time:month")
+)
+
+// QueryV2 represents a query to the /api/v2/query endpoint of the Stats API.
+//
+// A single QueryV2 can do what the aggregate, time series and breakdown queries of the v1 API do: without dimensions
+// it aggregates the metrics over the period, with time dimensions it reports a time series and with property
+// dimensions it breaks down the metrics by the values of the properties. Unlike v1 breakdowns, the results can be
+// broken down by several dimensions at once.
+// In a QueryV2, the Metrics and the Period fields are mandatory, all the others are optional.
+type QueryV2 struct {
+	// Metrics to be included in the results.
+	// This field is mandatory.
+	Metrics Metrics
+	// Period is the date range of the query.
+	// Periods relative to a date are only supported for DayPeriod and MonthPeriod, for other periods
+	// use CustomPeriod instead.
+	// This field is mandatory.
+	Period TimePeriod
+	// Dimensions are the properties by which to group the results. Besides the properties, the time
+	// dimensions, e.g. TimeDayDimension, can be used to group the results by periods of time.
+	// This field is optional.
+	Dimensions []PropertyName
+	// Filters narrow down the results. All the filters must match for an entry to be included in the results.
+	// Properties and filters can be used as query filters, and they can be combined with FilterAnd,
+	// FilterOr and FilterNot.
+	// This field is optional.
+	Filters []QueryFilter
+	// OrderBy sets the ordering of the results. Each entry must be one of the metrics or dimensions of the query.
+	// This field is optional.
+	OrderBy []QueryOrder
+	// Include sets additional information to include in the results.
+	// This field is optional.
+	Include QueryInclude
+	// Limit limits the number of results to be returned.
+	// This field is optional.
+	Limit int
+	// Offset is the number of results to skip, used together with Limit to paginate the results.
+	// This field is optional.
+	Offset int
+}
+
+// QueryFilter represents a filter of a QueryV2.
+// Property, Filter and the results of FilterAnd, FilterOr and FilterNot are query filters.
+type QueryFilter interface {
+	toQueryFilter() interface{}
+}
+
+type logicalQueryFilter struct {
+	operator string
+	filters  []QueryFilter
+}
+
+func (lf logicalQueryFilter) toQueryFilter() interface{} {
+	if lf.operator == "not" {
+		return []interface{}{lf.operator, lf.filters[0].toQueryFilter()}
+	}
+
+	operands := make([]interface{}, 0, len(lf.filters))
+	for _, f := range lf.filters {
+		operands = append(operands, f.toQueryFilter())
+	}
+
+	return []interface{}{lf.operator, operands}
+}
+
+// FilterAnd returns a query filter that matches when all the given filters match.
+func FilterAnd(filters ...QueryFilter) QueryFilter {
+	return logicalQueryFilter{operator: "and", filters: filters}
+}
+
+// FilterOr returns a query filter that matches when any of the given filters match.
+func FilterOr(filters ...QueryFilter) QueryFilter {
+	return logicalQueryFilter{operator: "or", filters: filters}
+}
+
+// FilterNot returns a query filter that matches when the given filter does not match.
+func FilterNot(filter QueryFilter) QueryFilter {
+	return logicalQueryFilter{operator: "not", filters: []QueryFilter{filter}}
+}
+
+func (p Property) toQueryFilter() interface{} {
+	operator := p.Operator.orDefault()
+	values := p.Values()
+
+	// In the v1 API, values of the equality operators with "*" are wildcard patterns, but the v2 API compares
+	// them exactly. Such values are sent with the matches operators to get the same results in both versions.
+	if (operator == FilterIs || operator == FilterIsNot) && strings.Contains(strings.Join(values, ""), "*") {
+		if operator == FilterIs {
+			operator = FilterMatches
+		} else {
+			operator = FilterMatchesNot
+		}
+	}
+
+	// In the v2 API, the matches operators take regular expressions instead of wildcard patterns.
+	if operator == FilterMatches || operator == FilterMatchesNot {
+		for i, v := range values {
+			values[i] = wildcardToRegexp(v)
+		}
+	}
+
+	return []interface{}{string(operator), string(p.Name), values}
+}
+
+// wildcardToRegexp converts a wildcard pattern to an equivalent regular expression.
+// In a pattern, "**" matches any sequence of characters and "*" matches any sequence of characters except "/".
+func wildcardToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+			i++
+		default:
+			next := strings.IndexByte(pattern[i:], '*')
+			if next == -1 {
+				next = len(pattern) - i
+			}
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+next]))
+			i += next
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
+
+func (f Filter) toQueryFilter() interface{} {
+	if f.Count() == 1 {
+		return f.Properties[0].toQueryFilter()
+	}
+
+	operands := make([]interface{}, 0, f.Count())
+	for _, p := range f.Properties {
+		operands = append(operands, p.toQueryFilter())
+	}
+
+	return []interface{}{"and", operands}
+}
+
+// validateQueryFilter checks if a query filter can be sent to the API.
+func validateQueryFilter(filter QueryFilter) (ok bool, invalidReason string) {
+	switch f := filter.(type) {
+	case nil:
+		return false, "filters can't be nil"
+	case Filter:
+		if f.IsEmpty() {
+			return false, "filters must have at least one property"
+		}
+	case Property:
+		if f.Name.IsEmpty() {
+			return false, "filters must have a property name"
+		}
+	case logicalQueryFilter:
+		if len(f.filters) == 0 {
+			return false, fmt.Sprintf("'%s' filters must have at least one filter", f.operator)
+		}
+		for _, inner := range f.filters {
+			if ok, invalidReason := validateQueryFilter(inner); !ok {
+				return false, invalidReason
+			}
+		}
+	}
+
+	return true, ""
+}
+
+// SortDirection represents the direction in which results are ordered.
+type SortDirection string
+
+const (
+	// Ascending orders results from the lowest to the highest value.
+	Ascending = SortDirection("asc")
+	// Descending orders results from the highest to the lowest value.
+	Descending = SortDirection("desc")
+)
+
+// QueryOrder represents an ordering of the results of a QueryV2.
+// Use OrderByMetric or OrderByDimension to build one.
+type QueryOrder struct {
+	// Field is the name of a metric or dimension of the query.
+	Field string
+	// Direction is the direction in which the results are ordered.
+	Direction SortDirection
+}
+
+// OrderByMetric orders the results of a query by the value of a metric.
+// The metric must be one of the metrics of the query.
+func OrderByMetric(metric Metric, direction SortDirection) QueryOrder {
+	return QueryOrder{Field: string(metric), Direction: direction}
+}
+
+// OrderByDimension orders the results of a query by the value of a dimension.
+// The dimension must be one of the dimensions of the query.
+func OrderByDimension(dimension PropertyName, direction SortDirection) QueryOrder {
+	return QueryOrder{Field: string(dimension), Direction: direction}
+}
+
+// QueryInclude sets additional information to include in the results of a QueryV2.
+type QueryInclude struct {
+	// Imports tells whether to include data imported from other tools, e.g. Google Analytics, in the results.
+	Imports bool
+	// TimeLabels tells whether to include in the meta information of the results the labels of all
+	// the time buckets of the period, including the ones without data.
+	// Only valid in queries with a time dimension.
+	TimeLabels bool
+	// TotalRows tells whether to include in the meta information of the results the total number of rows,
+	// ignoring Limit and Offset.
+	TotalRows bool
+}
+
+type rawQueryInclude struct {
+	Imports    bool `json:"imports,omitempty"`
+	TimeLabels bool `json:"time_labels,omitempty"`
+	TotalRows  bool `json:"total_rows,omitempty"`
+}
+
+type rawQueryPagination struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+type rawQueryV2 struct {
+	SiteID     string              `json:"site_id"`
+	Metrics    []string            `json:"metrics"`
+	DateRange  interface{}         `json:"date_range"`
+	Dimensions []string            `json:"dimensions,omitempty"`
+	Filters    []interface{}       `json:"filters,omitempty"`
+	OrderBy    [][]string          `json:"order_by,omitempty"`
+	Include    *rawQueryInclude    `json:"include,omitempty"`
+	Pagination *rawQueryPagination `json:"pagination,omitempty"`
+}
+
+// Validate tells whether the query is valid or not.
+// If the query is not valid, a string explaining why the query is not valid will be returned.
+func (q *QueryV2) Validate() (ok bool, invalidReason string) {
+	if q.Metrics.IsEmpty() {
+		return false, "at least one metric must be specified for a query"
+	}
+
+	if q.Period.IsEmpty() {
+		return false, "a period must be specified for a query"
+	}
+
+	if _, err := q.Period.toDateRange(); err != nil {
+		return false, err.Error()
+	}
+
+	for _, filter := range q.Filters {
+		if ok, invalidReason := validateQueryFilter(filter); !ok {
+			return false, invalidReason
+		}
+	}
+
+	fields := map[string]bool{}
+	for _, m := range q.Metrics {
+		fields[string(m)] = true
+	}
+	for _, d := range q.Dimensions {
+		fields[string(d)] = true
+	}
+
+	for _, order := range q.OrderBy {
+		if !fields[order.Field] {
+			return false, fmt.Sprintf("results can only be ordered by metrics or dimensions of the query, got '%s'", order.Field)
+		}
+		if order.Direction != Ascending && order.Direction != Descending {
+			return false, fmt.Sprintf("invalid order direction '%s' for '%s'", order.Direction, order.Field)
+		}
+	}
+
+	if q.Limit < 0 || q.Offset < 0 {
+		return false, "the limit and offset of a query can't be negative"
+	}
+
+	return true, ""
+}
+
+func (q *QueryV2) toJSON(siteID string) ([]byte, error) {
+	dateRange, err := q.Period.toDateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	raw := rawQueryV2{
+		SiteID:    siteID,
+		DateRange: dateRange,
+	}
+
+	for _, m := range q.Metrics {
+		raw.Metrics = append(raw.Metrics, string(m))
+	}
+
+	for _, d := range q.Dimensions {
+		raw.Dimensions = append(raw.Dimensions, string(d))
+	}
+
+	for _, f := range q.Filters {
+		raw.Filters = append(raw.Filters, f.toQueryFilter())
+	}
+
+	for _, o := range q.OrderBy {
+		raw.OrderBy = append(raw.OrderBy, []string{o.Field, string(o.Direction)})
+	}
+
+	if q.Include != (QueryInclude{}) {
+		raw.Include = &rawQueryInclude{
+			Imports:    q.Include.Imports,
+			TimeLabels: q.Include.TimeLabels,
+			TotalRows:  q.Include.TotalRows,
+		}
+	}
+
+	if q.Limit != 0 || q.Offset != 0 {
+		raw.Pagination = &rawQueryPagination{Limit: q.Limit, Offset: q.Offset}
+	}
+
+	return json.Marshal(raw)
+}
+
+// toDateRange converts the time period to the date range format of the v2 API.
+// The v2 API does not support periods relative to a date, so those are converted to custom ranges when possible.
+func (tp TimePeriod) toDateRange() (interface{}, error) {
+	if tp.Period == "custom" {
+		dates := strings.Split(tp.Date, ",")
+		if len(dates) != 2 {
+			return nil, fmt.Errorf("invalid date range '%s' for custom period", tp.Date)
+		}
+		return dates, nil
+	}
+
+	if tp.Date == "" {
+		return tp.Period, nil
+	}
+
+	date, err := time.Parse("2006-01-02", tp.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s' for period '%s'", tp.Date, tp.Period)
+	}
+
+	switch tp.Period {
+	case "day":
+		return []string{tp.Date, tp.Date}, nil
+	case "month":
+		first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1)
+		return []string{first.Format("2006-01-02"), last.Format("2006-01-02")}, nil
+	case "year":
+		first := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(1, 0, -1)
+		return []string{first.Format("2006-01-02"), last.Format("2006-01-02")}, nil
+	}
+
+	return nil, fmt.Errorf("the period '%s' relative to a date is not supported in v2 queries, use a custom period instead", tp.Period)
+}
+
+// QueryV2Result represents the result of a QueryV2.
+type QueryV2Result struct {
+	// Results contains a row for each combination of the values of the dimensions of the query.
+	Results []QueryV2ResultRow `json:"results"`
+	// Meta contains additional information about the results.
+	Meta QueryV2Meta `json:"meta"`
+}
+
+// QueryV2Meta contains additional information about the results of a QueryV2.
+type QueryV2Meta struct {
+	// ImportsIncluded tells whether imported data was included in the results.
+	ImportsIncluded bool `json:"imports_included"`
+	// ImportsSkipReason explains why imported data was not included in the results, if it was requested.
+	ImportsSkipReason string `json:"imports_skip_reason"`
+	// ImportsWarning contains a warning about the imported data included in the results, if any.
+	ImportsWarning string `json:"imports_warning"`
+	// TimeLabels contains the labels of all the time buckets of the period.
+	// Only present if the query included time labels.
+	TimeLabels []string `json:"time_labels"`
+	// TotalRows is the total number of rows of the results, ignoring the limit and offset of the query.
+	// Only present if the query included the total rows.
+	TotalRows int `json:"total_rows"`
+}
+
+// QueryV2ResultRow represents a row in the results of a QueryV2.
+type QueryV2ResultRow struct {
+	// Metrics contains the values of the metrics of the query, in the same order as the metrics of the query.
+	// Use the Metric method to get the value of a particular metric.
+	Metrics []float64
+	// Dimensions contains the values of the dimensions of the query, in the same order as the dimensions of the query.
+	// Use the Dimension method to get the value of a particular dimension.
+	Dimensions []string
+
+	metrics    Metrics
+	dimensions []PropertyName
+}
+
+// Metric returns the value of the given metric in this row.
+// It will return 0 (zero) if the metric was not part of the query or has no value.
+func (r *QueryV2ResultRow) Metric(metric Metric) float64 {
+	for i, m := range r.metrics {
+		if m == metric && i < len(r.Metrics) {
+			return r.Metrics[i]
+		}
+	}
+	return 0
+}
+
+// Dimension returns the value of the given dimension in this row.
+// It will return an empty string if the dimension was not part of the query.
+func (r *QueryV2ResultRow) Dimension(dimension PropertyName) string {
+	for i, d := range r.dimensions {
+		if d == dimension && i < len(r.Dimensions) {
+			return r.Dimensions[i]
+		}
+	}
+	return ""
+}
+
+// UnmarshalJSON decodes a row of the results.
+// Metrics without a value are decoded as zero, and metrics whose value is an object, like revenue metrics,
+// are decoded from the value field of the object.
+func (r *QueryV2ResultRow) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Metrics    []json.RawMessage `json:"metrics"`
+		Dimensions []json.RawMessage `json:"dimensions"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Metrics = make([]float64, 0, len(raw.Metrics))
+	for _, m := range raw.Metrics {
+		value, err := decodeMetricValue(m)
+		if err != nil {
+			return err
+		}
+		r.Metrics = append(r.Metrics, value)
+	}
+
+	r.Dimensions = make([]string, 0, len(raw.Dimensions))
+	for _, d := range raw.Dimensions {
+		var value string
+		if err := json.Unmarshal(d, &value); err != nil {
+			// Dimension values are usually strings, keep any other value as it was sent.
+			value = string(bytes.TrimSpace(d))
+		}
+		r.Dimensions = append(r.Dimensions, value)
+	}
+
+	return nil
+}
+
+func decodeMetricValue(data json.RawMessage) (float64, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return 0, nil
+	}
+
+	if data[0] == '{' {
+		var object struct {
+			Value *float64 `json:"value"`
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return 0, fmt.Errorf("decoding metric value %s: %w", data, err)
+		}
+		if object.Value == nil {
+			return 0, nil
+		}
+		return *object.Value, nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return 0, fmt.Errorf("decoding metric value %s: %w", data, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,232 @@
+package plausible
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnitValidateQueryV2(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   QueryV2
+		isValid bool
+	}{
+		{
+			name: "valid query with dimensions, filters and ordering",
+			query: QueryV2{
+				Metrics:    Metrics{Visitors, PageViews},
+				Period:     Last30Days(),
+				Dimensions: []PropertyName{VisitCountry, VisitDevice},
+				Filters:    []QueryFilter{NewFilter().ByEventPage("/"), FilterOr(Property{Name: VisitOs, Value: "Linux"})},
+				OrderBy:    []QueryOrder{OrderByMetric(Visitors, Descending), OrderByDimension(VisitCountry, Ascending)},
+			},
+			isValid: true,
+		},
+		{
+			name: "valid query with a day period relative to a date",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  DayPeriod().FromDate(Date{Day: 1, Month: 2, Year: 2021}),
+			},
+			isValid: true,
+		},
+		{
+			name: "invalid query due to missing metrics",
+			query: QueryV2{
+				Period: Last30Days(),
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid query due to missing period",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid query due to a rolling period relative to a date",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  Last7Days().FromDate(Date{Day: 1, Month: 2, Year: 2021}),
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid query due to ordering by a metric not in the query",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  Last30Days(),
+				OrderBy: []QueryOrder{OrderByMetric(PageViews, Descending)},
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid query due to an empty logical filter",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  Last30Days(),
+				Filters: []QueryFilter{FilterNot(FilterAnd())},
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid query due to negative limit",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  Last30Days(),
+				Limit:   -1,
+			},
+			isValid: false,
+		},
+	}
+
+	for _, test := range tests {
+		valid, _ := test.query.Validate()
+		if valid && !test.isValid {
+			t.Fatalf("test '%s' is valid, but was expected to fail", test.name)
+		}
+		if !valid && test.isValid {
+			t.Fatalf("test '%s' is invalid, but was expected to succeed", test.name)
+		}
+	}
+}
+
+func TestUnitToJSONQueryV2(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        QueryV2
+		expectedJSON string
+	}{
+		{
+			name: "query with only the mandatory fields",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  Last7Days(),
+			},
+			expectedJSON: `{"site_id":"example.com","metrics":["visitors"],"date_range":"7d"}`,
+		},
+		{
+			name: "query with a custom period, dimensions and nested filters",
+			query: QueryV2{
+				Metrics: Metrics{Visitors, PageViews},
+				Period: CustomPeriod(
+					Date{Day: 1, Month: 1, Year: 2021},
+					Date{Day: 1, Month: 2, Year: 2021},
+				),
+				Dimensions: []PropertyName{VisitCountry, TimeDayDimension},
+				Filters: []QueryFilter{
+					NewFilter().ByVisitOs("Windows").ByVisitBrowser("Firefox"),
+					FilterOr(Property{Name: EventPage, Value: "/"}, FilterNot(Property{Name: VisitDevice, Value: "Mobile"})),
+				},
+				OrderBy: []QueryOrder{OrderByMetric(Visitors, Descending)},
+				Include: QueryInclude{TotalRows: true},
+				Limit:   10,
+				Offset:  20,
+			},
+			expectedJSON: `{"site_id":"example.com","metrics":["visitors","pageviews"],` +
+				`"date_range":["2021-01-01","2021-02-01"],"dimensions":["visit:country","time:day"],` +
+				`"filters":[["and",[["is","visit:os",["Windows"]],["is","visit:browser",["Firefox"]]]],` +
+				`["or",[["is","event:page",["/"]],["not",["is","visit:device",["Mobile"]]]]]],` +
+				`"order_by":[["visitors","desc"]],"include":{"total_rows":true},"pagination":{"limit":10,"offset":20}}`,
+		},
+		{
+			name: "query with a month period relative to a date",
+			query: QueryV2{
+				Metrics: Metrics{Visitors},
+				Period:  MonthPeriod().FromDate(Date{Day: 15, Month: 2, Year: 2021}),
+			},
+			expectedJSON: `{"site_id":"example.com","metrics":["visitors"],"date_range":["2021-02-01","2021-02-28"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.query.toJSON("example.com")
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if string(got) != test.expectedJSON {
+			t.Fatalf("test '%s' failed: expected json\n%s\ngot\n%s", test.name, test.expectedJSON, got)
+		}
+	}
+}
+
+func TestUnitQueryV2Request(t *testing.T) {
+	var gotPath, gotBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+		_, _ = w.Write([]byte(`{
+			"results": [
+				{"metrics": [120, 33.5], "dimensions": ["DE", "Desktop"]},
+				{"metrics": [80, null], "dimensions": ["FR", "Mobile"]}
+			],
+			"meta": {"total_rows": 2},
+			"query": {}
+		}`))
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL+"/api/v1").Site("example.com")
+
+	res, err := site.Query(QueryV2{
+		Metrics:    Metrics{Visitors, BounceRate},
+		Period:     Last30Days(),
+		Dimensions: []PropertyName{VisitCountry, VisitDevice},
+		Include:    QueryInclude{TotalRows: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error performing query: %v", err)
+	}
+
+	if gotPath != "/api/v2/query" {
+		t.Fatalf("expected request to /api/v2/query, got %s", gotPath)
+	}
+
+	expectedBody := `{"site_id":"example.com","metrics":["visitors","bounce_rate"],"date_range":"30d",` +
+		`"dimensions":["visit:country","visit:device"],"include":{"total_rows":true}}`
+	if gotBody != expectedBody {
+		t.Fatalf("expected request body\n%s\ngot\n%s", expectedBody, gotBody)
+	}
+
+	if len(res.Results) != 2 || res.Meta.TotalRows != 2 {
+		t.Fatalf("expected 2 results and 2 total rows, got %d results and %d total rows", len(res.Results), res.Meta.TotalRows)
+	}
+
+	first, second := res.Results[0], res.Results[1]
+	if first.Dimension(VisitCountry) != "DE" || first.Dimension(VisitDevice) != "Desktop" {
+		t.Fatalf("unexpected dimensions in first row: %v", first.Dimensions)
+	}
+
+	if first.Metric(Visitors) != 120 || first.Metric(BounceRate) != 33.5 {
+		t.Fatalf("unexpected metrics in first row: %v", first.Metrics)
+	}
+
+	if second.Metric(BounceRate) != 0 || second.Metric(PageViews) != 0 {
+		t.Fatalf("expected metrics without value or not in the query to be 0, got %v", second.Metrics)
+	}
+}
+
+func TestUnitQueryV2BaseURLWithoutVersion(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	_, err := site.Query(QueryV2{Metrics: Metrics{Visitors}, Period: Last30Days()})
+	if err == nil {
+		t.Fatalf("expected an error for a base URL without an API version")
+	}
+
+	if requests != 0 {
+		t.Fatalf("expected no requests to be sent, got %d requests", requests)
+	}
+}
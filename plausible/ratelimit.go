@@ -0,0 +1,166 @@
+package plausible
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClientRateLimited is returned when a request is not sent because the client-side rate limiter,
+// configured with WithRateLimit, has no budget left and is set to fail fast.
+// This is different from ErrRateLimited, which is the class of errors for requests rejected by the API.
+var ErrClientRateLimited = errors.New("client-side rate limit exceeded")
+
+// RateLimit configures the client-side rate limiter of a client.
+//
+// The rate limiter is a token bucket: it starts full with Burst requests available, and each
+// request takes one of them, which is given back at the rate of RequestsPerHour.
+type RateLimit struct {
+	// RequestsPerHour is the number of requests per hour the client is allowed to make.
+	// The Stats API allows 600 requests per hour for each API key by default.
+	// This field is mandatory.
+	RequestsPerHour int
+	// Burst is the maximum number of requests that can be made at once after a period of inactivity.
+	// This field is optional and defaults to 1.
+	Burst int
+	// FailFast tells whether requests should fail with ErrClientRateLimited when there's no budget left.
+	// By default, requests wait until there's budget for them or until their context is done.
+	FailFast bool
+}
+
+// RateLimitBudget represents the state of the client-side rate limiter.
+type RateLimitBudget struct {
+	// Remaining is the number of requests that can be made right away.
+	Remaining int
+	// Burst is the maximum number of requests that can be made at once.
+	Burst int
+	// NextIn is the time until the budget grows by one request. It's zero when the budget is full.
+	NextIn time.Duration
+}
+
+// WithRateLimit sets a client-side rate limiter that limits the number of requests sent to the API.
+// The limiter is shared by all the sites handlers obtained from the client, and it applies to every attempt
+// of a request, including retries. Events sent with PushEvent are not limited, since they don't count
+// towards the quota of the API key.
+//
+// A rate limit with zero or less requests per hour disables the limiter, which is the default.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		if limit.RequestsPerHour <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newRateLimiter(limit, time.Now)
+	}
+}
+
+// RateLimitBudget returns the state of the client-side rate limiter.
+// If the client has no rate limiter, ok will be false.
+func (c *Client) RateLimitBudget() (budget RateLimitBudget, ok bool) {
+	if c.limiter == nil {
+		return RateLimitBudget{}, false
+	}
+
+	return c.limiter.budget(), true
+}
+
+// rateLimiter is a token bucket rate limiter, safe for concurrent use.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	burst    float64
+	failFast bool
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+func newRateLimiter(limit RateLimit, now func() time.Time) *rateLimiter {
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:     float64(limit.RequestsPerHour) / time.Hour.Seconds(),
+		burst:    float64(burst),
+		failFast: limit.FailFast,
+		tokens:   float64(burst),
+		last:     now(),
+		now:      now,
+	}
+}
+
+// refill adds the tokens earned since the last refill. It must be called with the lock held.
+func (rl *rateLimiter) refill() {
+	now := rl.now()
+	if elapsed := now.Sub(rl.last); elapsed > 0 {
+		rl.tokens += elapsed.Seconds() * rl.rate
+		if rl.tokens > rl.burst {
+			rl.tokens = rl.burst
+		}
+	}
+	rl.last = now
+}
+
+// durationFor returns the time needed for the bucket to have the given number of tokens.
+// It must be called with the lock held.
+func (rl *rateLimiter) durationFor(tokens float64) time.Duration {
+	missing := tokens - rl.tokens
+	if missing <= 0 {
+		return 0
+	}
+
+	return time.Duration(missing / rl.rate * float64(time.Second))
+}
+
+// wait takes a token from the bucket, waiting for one to be available if needed.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	rl.mu.Lock()
+	rl.refill()
+
+	if rl.tokens >= 1 {
+		rl.tokens--
+		rl.mu.Unlock()
+		return nil
+	}
+
+	if rl.failFast {
+		rl.mu.Unlock()
+		return ErrClientRateLimited
+	}
+
+	// Reserve the token right away, so requests are served in the order they arrived.
+	delay := rl.durationFor(1)
+	rl.tokens--
+	rl.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		rl.mu.Lock()
+		rl.refill()
+		rl.tokens++
+		rl.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// budget returns the current state of the bucket.
+func (rl *rateLimiter) budget() RateLimitBudget {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill()
+
+	budget := RateLimitBudget{Burst: int(rl.burst)}
+	if rl.tokens > 0 {
+		budget.Remaining = int(rl.tokens)
+	}
+	if rl.tokens < rl.burst {
+		budget.NextIn = rl.durationFor(float64(budget.Remaining + 1))
+	}
+
+	return budget
+}
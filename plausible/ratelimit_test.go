@@ -0,0 +1,114 @@
+package plausible
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnitRateLimiter(t *testing.T) {
+	now := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	// 3600 requests per hour means a new request every second
+	limiter := newRateLimiter(RateLimit{RequestsPerHour: 3600, Burst: 2, FailFast: true}, clock)
+
+	steps := []struct {
+		name              string
+		advance           time.Duration
+		shouldFail        bool
+		expectedRemaining int
+		expectedNextIn    time.Duration
+	}{
+		{name: "first request of the burst", shouldFail: false, expectedRemaining: 1, expectedNextIn: time.Second},
+		{name: "second request of the burst", shouldFail: false, expectedRemaining: 0, expectedNextIn: time.Second},
+		{name: "request without budget", shouldFail: true, expectedRemaining: 0, expectedNextIn: time.Second},
+		{name: "request after half the refill time", advance: 500 * time.Millisecond, shouldFail: true,
+			expectedRemaining: 0, expectedNextIn: 500 * time.Millisecond},
+		{name: "request after the refill time", advance: 500 * time.Millisecond, shouldFail: false,
+			expectedRemaining: 0, expectedNextIn: time.Second},
+		{name: "request after a long time", advance: time.Hour, shouldFail: false,
+			expectedRemaining: 1, expectedNextIn: time.Second},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		err := limiter.wait(context.Background())
+
+		if err != nil && !step.shouldFail {
+			t.Fatalf("step '%s' failed but was expected to succeed: %v", step.name, err)
+		}
+
+		if err == nil && step.shouldFail {
+			t.Fatalf("step '%s' was expected to fail, but succeeded", step.name)
+		}
+
+		if err != nil && !errors.Is(err, ErrClientRateLimited) {
+			t.Fatalf("step '%s' failed with unexpected error: %v", step.name, err)
+		}
+
+		budget := limiter.budget()
+		if budget.Remaining != step.expectedRemaining || budget.NextIn != step.expectedNextIn {
+			t.Fatalf("step '%s' failed: expected %d remaining requests and next in %v, got %d and %v",
+				step.name, step.expectedRemaining, step.expectedNextIn, budget.Remaining, budget.NextIn)
+		}
+	}
+}
+
+func TestUnitRateLimitSharedBySites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("token",
+		WithBaseURL(server.URL),
+		WithRateLimit(RateLimit{RequestsPerHour: 600, Burst: 2, FailFast: true}),
+	)
+
+	if _, err := client.Site("a.com").CurrentVisitors(); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	if _, err := client.Site("b.com").CurrentVisitors(); err != nil {
+		t.Fatalf("second request failed: %v", err)
+	}
+
+	if _, err := client.Site("c.com").CurrentVisitors(); !errors.Is(err, ErrClientRateLimited) {
+		t.Fatalf("expected third request to fail with %v, got %v", ErrClientRateLimited, err)
+	}
+
+	budget, ok := client.RateLimitBudget()
+	if !ok || budget.Remaining != 0 || budget.Burst != 2 {
+		t.Fatalf("unexpected budget after exhausting the rate limit: %+v (ok: %v)", budget, ok)
+	}
+
+	if _, ok := NewClient("token").RateLimitBudget(); ok {
+		t.Fatalf("expected client without rate limit to report no budget")
+	}
+}
+
+func TestUnitRateLimiterWaits(t *testing.T) {
+	// 360000 requests per hour means a new request every 10 milliseconds
+	limiter := newRateLimiter(RateLimit{RequestsPerHour: 360000}, time.Now)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error waiting for the rate limiter: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Fatalf("expected 3 requests with a burst of 1 to take at least 20ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected waiting with a cancelled context to fail with %v, got %v", context.Canceled, err)
+	}
+}
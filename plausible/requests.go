@@ -1,50 +1,86 @@
 package plausible
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-
-	"github.com/valyala/fasthttp"
+	"time"
 )
 
 type apiError struct {
 	Error string `json:"error"`
 }
 
-func checkAPIResponseForErrors(resp *fasthttp.Response) ([]byte, error) {
+// checkAPIResponseForErrors checks if the response is an error response.
+// Error responses are returned as an *APIError.
+func checkAPIResponseForErrors(resp *HTTPResponse) ([]byte, error) {
 
-	body := resp.Body()
-	status := resp.StatusCode()
+	body := resp.Body
+	status := resp.StatusCode
 
 	if status < 200 || status > 203 {
-		var errorJSON apiError
-		err := json.Unmarshal(body, &errorJSON)
+		apiErr := &APIError{
+			StatusCode: status,
+			RawBody:    body,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 
-		if err != nil {
-			return body, fmt.Errorf("non-ok code received (%d) from the API", status)
+		var errorJSON apiError
+		if err := json.Unmarshal(body, &errorJSON); err == nil {
+			apiErr.Message = errorJSON.Error
 		}
 
-		return body, fmt.Errorf("api error with code %d: %s", status, errorJSON.Error)
+		return body, apiErr
 	}
 
 	return body, nil
 }
 
-func doRequest(client *fasthttp.Client, req *fasthttp.Request) ([]byte, error) {
+// doRequest sends the request with the transport of the client and checks the response for errors.
+// Requests that fail are retried according to the retry policy of the client.
+func (c *Client) doRequest(ctx context.Context, req *HTTPRequest) ([]byte, error) {
+	maxAttempts := c.retryPolicy.maxAttemptsFor(req)
+
+	for attempt := 1; ; attempt++ {
+		body, err := c.doAttempt(ctx, req)
+		if err == nil {
+			return body, nil
+		}
 
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(resp)
+		// Errors caused by the context of the caller are final, everything else is up to the retry policy.
+		if attempt >= maxAttempts || ctx.Err() != nil || !c.retryPolicy.shouldRetry(err) {
+			return nil, err
+		}
 
-	err := client.Do(req, resp)
-	if err != nil {
-		return nil, err
+		wait := c.retryPolicy.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			// There's no time left for another attempt, so report the error we got.
+			return nil, err
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	body, err := checkAPIResponseForErrors(resp)
+// doAttempt makes a single attempt of sending the request.
+func (c *Client) doAttempt(ctx context.Context, req *HTTPRequest) ([]byte, error) {
+	if c.limiter != nil && !req.exemptFromRateLimit {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	resp, err := c.transport.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return body, nil
+	return checkAPIResponseForErrors(resp)
 }
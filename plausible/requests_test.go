@@ -0,0 +1,59 @@
+package plausible
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnitRequestContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		ctx           func() (context.Context, context.CancelFunc)
+		expectedError error
+	}{
+		{
+			name: "request with an already cancelled context",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return cancelledCtx, func() {}
+			},
+			expectedError: context.Canceled,
+		},
+		{
+			name: "request with a context that expires before the response arrives",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := test.ctx()
+		start := time.Now()
+		_, err := site.CurrentVisitorsContext(ctx)
+		cancel()
+
+		if !errors.Is(err, test.expectedError) {
+			t.Fatalf("test '%s' failed: expected error %v, got %v", test.name, test.expectedError, err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Fatalf("test '%s' failed: request took %v to return after the context was done", test.name, elapsed)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package plausible
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how a client retries requests that failed.
+//
+// Only idempotent requests are retried by default. The POST requests made by CreateNewSite and PushEvent
+// are only retried if RetryNonIdempotent is set, since retrying them could create a site or record an event twice.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// BaseBackoff is the time to wait before the first retry. The wait time doubles on each retry.
+	BaseBackoff time.Duration
+	// MaxBackoff is the maximum time to wait between two attempts. Zero means no maximum.
+	// It does not limit the wait time asked by the API in a Retry-After header.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the wait time, between 0 and 1, that is randomized to avoid many clients
+	// retrying at the same time. For instance, a jitter of 0.2 makes a wait of 1s last between 0.8s and 1s.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes of the responses that should be retried.
+	// Requests that fail without a response, e.g. due to network errors, are always retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent enables retries of requests that are not idempotent, i.e. POST requests.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a retry policy that makes up to 3 attempts of a request, waiting half a second
+// before the first retry. It retries requests that were rate limited (429) and requests that failed with
+// a 502, 503 or 504 status code, which self-hosted instances can return while being deployed.
+//
+// Clients don't retry requests unless a retry policy is set with WithRetryPolicy:
+//
+//	client := plausible.NewClientWithOptions("<your_api_token>", plausible.WithRetryPolicy(plausible.DefaultRetryPolicy()))
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy sets the policy the client uses to retry requests that failed.
+// By default, requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// maxAttemptsFor returns the maximum number of attempts for the given request.
+func (rp *RetryPolicy) maxAttemptsFor(req *HTTPRequest) int {
+	if rp.MaxAttempts < 1 {
+		return 1
+	}
+
+	if !req.idempotent && !rp.RetryNonIdempotent {
+		return 1
+	}
+
+	return rp.MaxAttempts
+}
+
+// shouldRetry tells whether a request that failed with the given error should be retried.
+func (rp *RetryPolicy) shouldRetry(err error) bool {
+	if errors.Is(err, ErrClientRateLimited) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// The request failed without a response.
+		return true
+	}
+
+	for _, code := range rp.RetryableStatusCodes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the time to wait before the next attempt, after the given attempt failed with the given error.
+// Attempts start at 1.
+func (rp *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+
+	wait := rp.BaseBackoff
+	for i := 1; i < attempt && (rp.MaxBackoff <= 0 || wait < rp.MaxBackoff); i++ {
+		wait *= 2
+	}
+
+	if rp.MaxBackoff > 0 && wait > rp.MaxBackoff {
+		wait = rp.MaxBackoff
+	}
+
+	if rp.Jitter > 0 && wait > 0 {
+		jitter := rp.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		// #nosec G404 -- the jitter does not need a cryptographically secure random number.
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+
+	return wait
+}
+
+// isIdempotentMethod tells whether requests with the given HTTP method are idempotent.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// sleepContext waits for the given duration or until the context is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package plausible
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnitRetries(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:          3,
+		BaseBackoff:          time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusBadGateway},
+	}
+	policyWithPOST := policy
+	policyWithPOST.RetryNonIdempotent = true
+
+	tests := []struct {
+		name             string
+		policy           RetryPolicy
+		failures         int32
+		failureStatus    int
+		request          func(c *Client) error
+		shouldFail       bool
+		expectedAttempts int32
+	}{
+		{
+			name:             "request without retry policy is not retried",
+			policy:           RetryPolicy{},
+			failures:         1,
+			failureStatus:    http.StatusBadGateway,
+			request:          func(c *Client) error { _, err := c.Site("example.com").CurrentVisitors(); return err },
+			shouldFail:       true,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "get request that succeeds after retries",
+			policy:           policy,
+			failures:         2,
+			failureStatus:    http.StatusBadGateway,
+			request:          func(c *Client) error { _, err := c.Site("example.com").CurrentVisitors(); return err },
+			shouldFail:       false,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "get request that fails more times than the maximum attempts",
+			policy:           policy,
+			failures:         5,
+			failureStatus:    http.StatusBadGateway,
+			request:          func(c *Client) error { _, err := c.Site("example.com").CurrentVisitors(); return err },
+			shouldFail:       true,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "get request with status code that is not retryable",
+			policy:           policy,
+			failures:         1,
+			failureStatus:    http.StatusNotFound,
+			request:          func(c *Client) error { _, err := c.Site("example.com").CurrentVisitors(); return err },
+			shouldFail:       true,
+			expectedAttempts: 1,
+		},
+		{
+			name:          "post request to create site is not retried by default",
+			policy:        policy,
+			failures:      1,
+			failureStatus: http.StatusBadGateway,
+			request: func(c *Client) error {
+				_, err := c.CreateNewSite(CreateSiteRequest{Domain: "example.com"})
+				return err
+			},
+			shouldFail:       true,
+			expectedAttempts: 1,
+		},
+		{
+			name:          "post request to create site is retried if non idempotent requests can be retried",
+			policy:        policyWithPOST,
+			failures:      1,
+			failureStatus: http.StatusBadGateway,
+			request: func(c *Client) error {
+				_, err := c.CreateNewSite(CreateSiteRequest{Domain: "example.com"})
+				return err
+			},
+			shouldFail:       false,
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) <= test.failures {
+				w.WriteHeader(test.failureStatus)
+				return
+			}
+			if r.Method == http.MethodPost {
+				_, _ = w.Write([]byte(`{"domain":"example.com","timezone":"Etc/UTC"}`))
+				return
+			}
+			_, _ = w.Write([]byte("42"))
+		}))
+
+		client := NewClientWithOptions("token", WithBaseURL(server.URL), WithRetryPolicy(test.policy))
+		err := test.request(client)
+		server.Close()
+
+		if err != nil && !test.shouldFail {
+			t.Fatalf("test '%s' failed but was expected to succeed: %v", test.name, err)
+		}
+
+		if err == nil && test.shouldFail {
+			t.Fatalf("test '%s' was expected to fail, but succeeded", test.name)
+		}
+
+		if attempts != test.expectedAttempts {
+			t.Fatalf("test '%s' failed: expected %d attempts, got %d", test.name, test.expectedAttempts, attempts)
+		}
+	}
+}
+
+func TestUnitRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseBackoff: time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		err      error
+		expected time.Duration
+	}{
+		{
+			name:     "first retry waits the base backoff",
+			attempt:  1,
+			err:      errors.New("connection reset"),
+			expected: time.Second,
+		},
+		{
+			name:     "third retry waits four times the base backoff",
+			attempt:  3,
+			err:      &APIError{StatusCode: http.StatusBadGateway},
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "backoff is capped by the max backoff",
+			attempt:  10,
+			err:      &APIError{StatusCode: http.StatusBadGateway},
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "retry after from the API takes precedence",
+			attempt:  1,
+			err:      &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute},
+			expected: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		got := policy.backoff(test.attempt, test.err)
+		if got != test.expected {
+			t.Fatalf("test '%s' failed: expected backoff of %v, got %v", test.name, test.expected, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(2, errors.New("connection reset"))
+		if got < time.Second || got > 2*time.Second {
+			t.Fatalf("backoff with jitter of 0.5 out of range: expected between 1s and 2s, got %v", got)
+		}
+	}
+}
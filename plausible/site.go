@@ -1,12 +1,14 @@
 package plausible
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
-	"github.com/valyala/fasthttp"
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
 )
 
 // Site represents a site added to plausible and implements a client
@@ -16,8 +18,8 @@ import (
 type Site struct {
 	token           string
 	id              string
-	httpClient      *fasthttp.Client
 	plausibleClient *Client
+	location        *time.Location
 }
 
 // ID returns the ID of the site.
@@ -25,22 +27,33 @@ func (s *Site) ID() string {
 	return s.id
 }
 
-func (s *Site) acquireRequest(method, endpoint string, queries QueryArgs, formVals QueryArgs) (*fasthttp.Request, error) {
-	req, err := s.plausibleClient.acquireRequest(method, endpoint, queries, formVals)
-	if err != nil {
-		return nil, err
-	}
-	req.URI().QueryArgs().Add("site_id", s.id)
-	return req, nil
+// WithTimezone returns a copy of the site handler that knows the timezone of the site. The timezone is used to
+// convert the dates of the results to time.Time values, e.g in TimeseriesDataPoint.Time.
+// The timezone of a site can be found with the Details method and loaded with time.LoadLocation.
+func (s *Site) WithTimezone(timezone *time.Location) *Site {
+	site := *s
+	site.location = timezone
+	return &site
+}
+
+// Timezone returns the timezone of the site given in WithTimezone, or nil if the timezone is not known.
+func (s *Site) Timezone() *time.Location {
+	return s.location
 }
 
-func (s *Site) doRequest(method, endpoint string, queries QueryArgs, formVals QueryArgs) ([]byte, error) {
-	req, err := s.acquireRequest(method, endpoint, queries, formVals)
+func (s *Site) newRequest(method, endpoint string, queries QueryArgs, formVals QueryArgs) (*HTTPRequest, error) {
+	siteQueries := QueryArgs{}
+	siteQueries.Merge(queries, QueryArgs{{Name: "site_id", Value: s.id}})
+	return s.plausibleClient.newRequest(method, endpoint, siteQueries, formVals)
+}
+
+func (s *Site) doRequest(ctx context.Context, method, endpoint string, queries QueryArgs, formVals QueryArgs) ([]byte, error) {
+	req, err := s.newRequest(method, endpoint, queries, formVals)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := doRequest(s.httpClient, req)
+	data, err := s.plausibleClient.doRequest(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -49,14 +62,20 @@ func (s *Site) doRequest(method, endpoint string, queries QueryArgs, formVals Qu
 
 // CurrentVisitors gets the current visitors for the site.
 func (s *Site) CurrentVisitors() (int, error) {
-	data, err := s.doRequest("GET", "stats/realtime/visitors", nil, nil)
+	return s.CurrentVisitorsContext(context.Background())
+}
+
+// CurrentVisitorsContext is like CurrentVisitors, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) CurrentVisitorsContext(ctx context.Context) (int, error) {
+	data, err := s.doRequest(ctx, "GET", "stats/realtime/visitors", nil, nil)
 	if err != nil {
 		return 0, fmt.Errorf("error performing current visitors request: %w", err)
 	}
 
 	visitors, err := strconv.Atoi(string(data))
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("error parsing current visitors response: %w", err)
 	}
 
 	return visitors, nil
@@ -64,7 +83,13 @@ func (s *Site) CurrentVisitors() (int, error) {
 
 // Details contains information about a site
 func (s *Site) Details() (SiteResult, error) {
-	data, err := s.doRequest("GET", fmt.Sprintf("sites/%s", s.id), nil, nil)
+	return s.DetailsContext(context.Background())
+}
+
+// DetailsContext is like Details, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) DetailsContext(ctx context.Context) (SiteResult, error) {
+	data, err := s.doRequest(ctx, "GET", fmt.Sprintf("sites/%s", s.id), nil, nil)
 	if err != nil {
 		return SiteResult{}, fmt.Errorf("error performing get request: %w", err)
 	}
@@ -78,17 +103,78 @@ func (s *Site) Details() (SiteResult, error) {
 	return res, nil
 }
 
+// Update changes the domain or the timezone of the site.
+// After a change of domain, the site must be accessed with a new handler for the new domain.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) Update(request UpdateSiteRequest) (UpdateSiteResult, error) {
+	return s.UpdateContext(context.Background(), request)
+}
+
+// UpdateContext is like Update, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) UpdateContext(ctx context.Context, request UpdateSiteRequest) (UpdateSiteResult, error) {
+	ok, invalidReason := request.Validate()
+	if !ok {
+		return UpdateSiteResult{}, errors.New("invalid request to update site: " + invalidReason)
+	}
+
+	data, err := s.doRequest(ctx, "PUT", fmt.Sprintf("sites/%s", s.id), nil, request.toFormArgs())
+	if err != nil {
+		return UpdateSiteResult{}, fmt.Errorf("error performing request to update site: %w", err)
+	}
+
+	var res UpdateSiteResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return UpdateSiteResult{}, fmt.Errorf("error parsing update site response: %w", err)
+	}
+
+	return res, nil
+}
+
+// Delete deletes the site and all of its stats. This action can't be undone.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) Delete() (DeleteSiteResult, error) {
+	return s.DeleteContext(context.Background())
+}
+
+// DeleteContext is like Delete, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) DeleteContext(ctx context.Context) (DeleteSiteResult, error) {
+	data, err := s.doRequest(ctx, "DELETE", fmt.Sprintf("sites/%s", s.id), nil, nil)
+	if err != nil {
+		return DeleteSiteResult{}, fmt.Errorf("error performing request to delete site: %w", err)
+	}
+
+	var res DeleteSiteResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return DeleteSiteResult{}, fmt.Errorf("error parsing delete site response: %w", err)
+	}
+
+	return res, nil
+}
+
 // Aggregate performs an aggregate query.
 // An aggregate query reports data for metrics aggregated over a period of time,
 // eg, "total number of visitors/pageviews for a particular day".
 func (s *Site) Aggregate(query AggregateQuery) (AggregateResult, error) {
+	return s.AggregateContext(context.Background(), query)
+}
 
-	ok, invalidReason := query.Validate()
-	if !ok {
-		return AggregateResult{}, errors.New("invalid aggregate query: " + invalidReason)
+// AggregateContext is like Aggregate, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) AggregateContext(ctx context.Context, query AggregateQuery) (AggregateResult, error) {
+
+	if err := query.Check(); err != nil {
+		return AggregateResult{}, err
 	}
 
-	data, err := s.doRequest("GET", "stats/aggregate", query.toQueryArgs(), nil)
+	data, err := s.doRequest(ctx, "GET", "stats/aggregate", query.toQueryArgs(), nil)
 	if err != nil {
 		return AggregateResult{}, fmt.Errorf("error performing aggregate request: %w", err)
 	}
@@ -107,13 +193,18 @@ func (s *Site) Aggregate(query AggregateQuery) (AggregateResult, error) {
 // where each data point contains data about metrics for that period of time.
 // e.g, "total number of visitors and page views for each day in the last month".
 func (s *Site) Timeseries(query TimeseriesQuery) (TimeseriesResult, error) {
+	return s.TimeseriesContext(context.Background(), query)
+}
 
-	ok, invalidReason := query.Validate()
-	if !ok {
-		return TimeseriesResult{}, errors.New("invalid timeline query: " + invalidReason)
+// TimeseriesContext is like Timeseries, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) TimeseriesContext(ctx context.Context, query TimeseriesQuery) (TimeseriesResult, error) {
+
+	if err := query.Check(); err != nil {
+		return TimeseriesResult{}, err
 	}
 
-	data, err := s.doRequest("GET", "stats/timeseries", query.toQueryArgs(), nil)
+	data, err := s.doRequest(ctx, "GET", "stats/timeseries", query.toQueryArgs(), nil)
 	if err != nil {
 		return TimeseriesResult{}, fmt.Errorf("error performing timeline request: %w", err)
 	}
@@ -124,6 +215,11 @@ func (s *Site) Timeseries(query TimeseriesQuery) (TimeseriesResult, error) {
 		return TimeseriesResult{}, fmt.Errorf("error parsing timeline response: %w", err)
 	}
 
+	for i := range res.Results {
+		res.Results[i].interval = query.Interval
+		res.Results[i].location = s.location
+	}
+
 	return res.Results, nil
 }
 
@@ -131,13 +227,18 @@ func (s *Site) Timeseries(query TimeseriesQuery) (TimeseriesResult, error) {
 // A breakdown query reports stats for the value of a given property over a period of time,
 // e.g, "total number of visitors and page views for each operating system in the last month".
 func (s *Site) Breakdown(query BreakdownQuery) (BreakdownResult, error) {
+	return s.BreakdownContext(context.Background(), query)
+}
 
-	ok, invalidReason := query.Validate()
-	if !ok {
-		return BreakdownResult{}, errors.New("invalid breakdown query: " + invalidReason)
+// BreakdownContext is like Breakdown, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) BreakdownContext(ctx context.Context, query BreakdownQuery) (BreakdownResult, error) {
+
+	if err := query.Check(); err != nil {
+		return BreakdownResult{}, err
 	}
 
-	data, err := s.doRequest("GET", "stats/breakdown", query.toQueryArgs(), nil)
+	data, err := s.doRequest(ctx, "GET", "stats/breakdown", query.toQueryArgs(), nil)
 	if err != nil {
 		return BreakdownResult{}, fmt.Errorf("error performing breakdown request: %w", err)
 	}
@@ -151,21 +252,123 @@ func (s *Site) Breakdown(query BreakdownQuery) (BreakdownResult, error) {
 	return res.Results, nil
 }
 
+// BreakdownAll performs a breakdown query for all the pages of results, starting at the page of the query,
+// and returns the entries of all the pages. The limit of the query is used as the size of the pages.
+// If maxRows is greater than zero, at most maxRows entries are returned.
+// Fetching stops after a page with fewer entries than the limit of the query.
+func (s *Site) BreakdownAll(query BreakdownQuery, maxRows int) (BreakdownResult, error) {
+	return s.BreakdownAllContext(context.Background(), query, maxRows)
+}
+
+// BreakdownAllContext is like BreakdownAll, but the requests are bound to the given context.
+// The requests are aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) BreakdownAllContext(ctx context.Context, query BreakdownQuery, maxRows int) (BreakdownResult, error) {
+	var res BreakdownResult
+
+	it := s.BreakdownIterContext(ctx, query, maxRows)
+	for it.Next() {
+		res = append(res, it.Entry())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BreakdownIter returns an iterator over the entries of all the pages of results of a breakdown query,
+// starting at the page of the query. Pages are only fetched when the iteration reaches them, and the limit of
+// the query is used as the size of the pages, even if maxRows is smaller than it.
+// If maxRows is greater than zero, the iteration stops after maxRows entries.
+func (s *Site) BreakdownIter(query BreakdownQuery, maxRows int) *BreakdownIterator {
+	return s.BreakdownIterContext(context.Background(), query, maxRows)
+}
+
+// BreakdownIterContext is like BreakdownIter, but the requests of the iterator are bound to the given context.
+// The requests are aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) BreakdownIterContext(ctx context.Context, query BreakdownQuery, maxRows int) *BreakdownIterator {
+	return newBreakdownIterator(ctx, s, query, maxRows)
+}
+
+// Query performs a query to the v2 Stats API.
+// A v2 query reports the given metrics over a period of time, optionally grouped by one or more dimensions,
+// e.g, "total number of visitors for each country and device in the last month".
+//
+// The v2 endpoint is found by replacing the version in the base URL of the client, e.g "/api/v1/" with "/api/v2/".
+// Clients with a base URL without a version can't make v2 queries.
+func (s *Site) Query(query QueryV2) (QueryV2Result, error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is like Query, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) QueryContext(ctx context.Context, query QueryV2) (QueryV2Result, error) {
+
+	ok, invalidReason := query.Validate()
+	if !ok {
+		return QueryV2Result{}, errors.New("invalid query: " + invalidReason)
+	}
+
+	body, err := query.toJSON(s.id)
+	if err != nil {
+		return QueryV2Result{}, fmt.Errorf("error encoding query: %w", err)
+	}
+
+	c := s.plausibleClient
+	baseURL, err := c.baseURLForVersion("v2")
+	if err != nil {
+		return QueryV2Result{}, fmt.Errorf("error building request: %w", err)
+	}
+
+	req, err := c.newRequestWithBaseURL(baseURL, "POST", "query", nil, nil)
+	if err != nil {
+		return QueryV2Result{}, fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Body = body
+	// The query endpoint only reads data, so it's safe to retry even though it's a POST.
+	req.idempotent = true
+
+	data, err := c.doRequest(ctx, req)
+	if err != nil {
+		return QueryV2Result{}, fmt.Errorf("error performing query request: %w", err)
+	}
+
+	var res QueryV2Result
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return QueryV2Result{}, fmt.Errorf("error parsing query response: %w", err)
+	}
+
+	for i := range res.Results {
+		res.Results[i].metrics = query.Metrics
+		res.Results[i].dimensions = query.Dimensions
+	}
+
+	return res, nil
+}
+
 // SharedLink creates a shared link with a given name.
 // If the link already exists, its information will be returned.
 //
 // Note: This endpoint requires an API token with permissions to use the sites provisioning API.
 // Check https://plausible.io/docs/sites-api for more info
 func (s *Site) SharedLink(query SharedLinkRequest) (SharedLinkResult, error) {
+	return s.SharedLinkContext(context.Background(), query)
+}
+
+// SharedLinkContext is like SharedLink, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) SharedLinkContext(ctx context.Context, query SharedLinkRequest) (SharedLinkResult, error) {
 
 	ok, invalidReason := query.Validate()
 	if !ok {
 		return SharedLinkResult{}, errors.New("invalid shared link request: " + invalidReason)
 	}
 
-	data, err := s.doRequest("PUT", "sites/shared-links", nil, query.toFormArgs(s.ID()))
+	data, err := s.doRequest(ctx, "PUT", "sites/shared-links", nil, query.toFormArgs(s.ID()))
 	if err != nil {
-		return SharedLinkResult{}, fmt.Errorf("error performing shared link request: %v", err)
+		return SharedLinkResult{}, fmt.Errorf("error performing shared link request: %w", err)
 	}
 
 	var res SharedLinkResult
@@ -176,3 +379,317 @@ func (s *Site) SharedLink(query SharedLinkRequest) (SharedLinkResult, error) {
 
 	return res, nil
 }
+
+// CreateGoal creates a goal for the site, completed either by custom events with a given name or by pageviews
+// of a given page path. If the goal already exists, its information will be returned.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) CreateGoal(request CreateGoalRequest) (GoalResult, error) {
+	return s.CreateGoalContext(context.Background(), request)
+}
+
+// CreateGoalContext is like CreateGoal, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) CreateGoalContext(ctx context.Context, request CreateGoalRequest) (GoalResult, error) {
+	ok, invalidReason := request.Validate()
+	if !ok {
+		return GoalResult{}, errors.New("invalid request to create goal: " + invalidReason)
+	}
+
+	data, err := s.doRequest(ctx, "PUT", "sites/goals", nil, request.toFormArgs(s.ID()))
+	if err != nil {
+		return GoalResult{}, fmt.Errorf("error performing request to create goal: %w", err)
+	}
+
+	var res GoalResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return GoalResult{}, fmt.Errorf("error parsing create goal response: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListGoals lists the goals of the site.
+// If the site has a lot of goals, the response will be paginated. Use the pagination options to access other pages.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) ListGoals(pagOptions ...pagination.Option) (ListGoalsResult, error) {
+	return s.ListGoalsContext(context.Background(), pagOptions...)
+}
+
+// ListGoalsContext is like ListGoals, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) ListGoalsContext(ctx context.Context, pagOptions ...pagination.Option) (ListGoalsResult, error) {
+	paginator := pagination.NewPaginator(pagOptions...)
+
+	data, err := s.doRequest(ctx, "GET", "sites/goals", QueryArgsFromPaginator(paginator), nil)
+	if err != nil {
+		return ListGoalsResult{}, fmt.Errorf("error performing request to list goals: %w", err)
+	}
+
+	var res ListGoalsResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return ListGoalsResult{}, fmt.Errorf("error parsing list goals response: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteGoal deletes the goal of the site with the given ID.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) DeleteGoal(goalID int) (DeleteGoalResult, error) {
+	return s.DeleteGoalContext(context.Background(), goalID)
+}
+
+// DeleteGoalContext is like DeleteGoal, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) DeleteGoalContext(ctx context.Context, goalID int) (DeleteGoalResult, error) {
+	data, err := s.doRequest(ctx, "DELETE", fmt.Sprintf("sites/goals/%d", goalID), nil, nil)
+	if err != nil {
+		return DeleteGoalResult{}, fmt.Errorf("error performing request to delete goal: %w", err)
+	}
+
+	var res DeleteGoalResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return DeleteGoalResult{}, fmt.Errorf("error parsing delete goal response: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListCustomProperties lists the custom properties enabled on the site.
+// Breakdowns and filters by a custom property only return data if the property is enabled.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) ListCustomProperties() (ListCustomPropertiesResult, error) {
+	return s.ListCustomPropertiesContext(context.Background())
+}
+
+// ListCustomPropertiesContext is like ListCustomProperties, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) ListCustomPropertiesContext(ctx context.Context) (ListCustomPropertiesResult, error) {
+	data, err := s.doRequest(ctx, "GET", "sites/custom-props", nil, nil)
+	if err != nil {
+		return ListCustomPropertiesResult{}, fmt.Errorf("error performing request to list custom properties: %w", err)
+	}
+
+	var res ListCustomPropertiesResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return ListCustomPropertiesResult{}, fmt.Errorf("error parsing list custom properties response: %w", err)
+	}
+
+	return res, nil
+}
+
+// AddCustomProperty enables a custom property on the site.
+// The name of the property can be given with or without the "event:props:" prefix of CustomPropertyName.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) AddCustomProperty(property string) (AddCustomPropertyResult, error) {
+	return s.AddCustomPropertyContext(context.Background(), property)
+}
+
+// AddCustomPropertyContext is like AddCustomProperty, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) AddCustomPropertyContext(ctx context.Context, property string) (AddCustomPropertyResult, error) {
+	ok, invalidReason := validateCustomPropertyName(property)
+	if !ok {
+		return AddCustomPropertyResult{}, errors.New("invalid request to add custom property: " + invalidReason)
+	}
+
+	formArgs := QueryArgs{
+		{Name: "site_id", Value: s.ID()},
+		{Name: "property", Value: customPropertyBareName(property)},
+	}
+
+	data, err := s.doRequest(ctx, "PUT", "sites/custom-props", nil, formArgs)
+	if err != nil {
+		return AddCustomPropertyResult{}, fmt.Errorf("error performing request to add custom property: %w", err)
+	}
+
+	var res AddCustomPropertyResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return AddCustomPropertyResult{}, fmt.Errorf("error parsing add custom property response: %w", err)
+	}
+
+	return res, nil
+}
+
+// RemoveCustomProperty disables a custom property on the site.
+// The name of the property can be given with or without the "event:props:" prefix of CustomPropertyName.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) RemoveCustomProperty(property string) (RemoveCustomPropertyResult, error) {
+	return s.RemoveCustomPropertyContext(context.Background(), property)
+}
+
+// RemoveCustomPropertyContext is like RemoveCustomProperty, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) RemoveCustomPropertyContext(ctx context.Context, property string) (RemoveCustomPropertyResult, error) {
+	ok, invalidReason := validateCustomPropertyName(property)
+	if !ok {
+		return RemoveCustomPropertyResult{}, errors.New("invalid request to remove custom property: " + invalidReason)
+	}
+
+	data, err := s.doRequest(ctx, "DELETE", customPropertyPath(property), nil, nil)
+	if err != nil {
+		return RemoveCustomPropertyResult{}, fmt.Errorf("error performing request to remove custom property: %w", err)
+	}
+
+	var res RemoveCustomPropertyResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return RemoveCustomPropertyResult{}, fmt.Errorf("error parsing remove custom property response: %w", err)
+	}
+
+	return res, nil
+}
+
+// EnsureCustomProperties makes sure that the given custom properties are enabled on the site, enabling the ones
+// that are not. It returns the names of the properties it enabled, which are empty if all of them were already enabled.
+// The names of the properties can be given with or without the "event:props:" prefix of CustomPropertyName.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) EnsureCustomProperties(properties ...string) ([]string, error) {
+	return s.EnsureCustomPropertiesContext(context.Background(), properties...)
+}
+
+// EnsureCustomPropertiesContext is like EnsureCustomProperties, but the requests are bound to the given context.
+// The requests are aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) EnsureCustomPropertiesContext(ctx context.Context, properties ...string) ([]string, error) {
+	for _, property := range properties {
+		ok, invalidReason := validateCustomPropertyName(property)
+		if !ok {
+			return nil, errors.New("invalid request to ensure custom properties: " + invalidReason)
+		}
+	}
+
+	enabled, err := s.ListCustomPropertiesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var added []string
+	for _, property := range properties {
+		property = customPropertyBareName(property)
+		if enabled.Has(property) {
+			continue
+		}
+
+		if _, err := s.AddCustomPropertyContext(ctx, property); err != nil {
+			return added, err
+		}
+		added = append(added, property)
+		enabled.CustomProperties = append(enabled.CustomProperties, CustomPropertyResult{Property: property})
+	}
+
+	return added, nil
+}
+
+// ListGuests lists the guests of the site, including the ones that didn't accept their invitation yet.
+// If the site has a lot of guests, the response will be paginated. Use the pagination options to access other pages.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) ListGuests(pagOptions ...pagination.Option) (ListGuestsResult, error) {
+	return s.ListGuestsContext(context.Background(), pagOptions...)
+}
+
+// ListGuestsContext is like ListGuests, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) ListGuestsContext(ctx context.Context, pagOptions ...pagination.Option) (ListGuestsResult, error) {
+	paginator := pagination.NewPaginator(pagOptions...)
+
+	data, err := s.doRequest(ctx, "GET", "sites/guests", QueryArgsFromPaginator(paginator), nil)
+	if err != nil {
+		return ListGuestsResult{}, fmt.Errorf("error performing request to list guests: %w", err)
+	}
+
+	var res ListGuestsResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return ListGuestsResult{}, fmt.Errorf("error parsing list guests response: %w", err)
+	}
+
+	return res, nil
+}
+
+// InviteGuest invites a guest to the site with the given role.
+// If the guest was already invited, its information will be returned.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) InviteGuest(email string, role GuestRole) (GuestResult, error) {
+	return s.InviteGuestContext(context.Background(), email, role)
+}
+
+// InviteGuestContext is like InviteGuest, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) InviteGuestContext(ctx context.Context, email string, role GuestRole) (GuestResult, error) {
+	ok, invalidReason := validateGuestInvitation(email, role)
+	if !ok {
+		return GuestResult{}, errors.New("invalid request to invite guest: " + invalidReason)
+	}
+
+	formArgs := QueryArgs{
+		{Name: "site_id", Value: s.ID()},
+		{Name: "email", Value: email},
+		{Name: "role", Value: string(role)},
+	}
+
+	data, err := s.doRequest(ctx, "PUT", "sites/guests", nil, formArgs)
+	if err != nil {
+		return GuestResult{}, fmt.Errorf("error performing request to invite guest: %w", err)
+	}
+
+	var res GuestResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return GuestResult{}, fmt.Errorf("error parsing invite guest response: %w", err)
+	}
+
+	return res, nil
+}
+
+// RemoveGuest removes a guest from the site, or cancels the invitation if the guest didn't accept it yet.
+//
+// Note: This endpoint requires an API token with permissions to use the sites provisioning API.
+// Check https://plausible.io/docs/sites-api for more info
+func (s *Site) RemoveGuest(email string) (RemoveGuestResult, error) {
+	return s.RemoveGuestContext(context.Background(), email)
+}
+
+// RemoveGuestContext is like RemoveGuest, but the request is bound to the given context.
+// The request is aborted as soon as the context is cancelled or its deadline expires.
+func (s *Site) RemoveGuestContext(ctx context.Context, email string) (RemoveGuestResult, error) {
+	ok, invalidReason := validateGuestEmail(email)
+	if !ok {
+		return RemoveGuestResult{}, errors.New("invalid request to remove guest: " + invalidReason)
+	}
+
+	data, err := s.doRequest(ctx, "DELETE", guestPath(email), nil, nil)
+	if err != nil {
+		return RemoveGuestResult{}, fmt.Errorf("error performing request to remove guest: %w", err)
+	}
+
+	var res RemoveGuestResult
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return RemoveGuestResult{}, fmt.Errorf("error parsing remove guest response: %w", err)
+	}
+
+	return res, nil
+}
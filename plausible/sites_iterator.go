@@ -0,0 +1,92 @@
+package plausible
+
+import (
+	"context"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+// SitesIterator iterates over all the sites of the account, fetching a page of sites at a time and following
+// the After cursor of the pagination information of each page until there are no more pages.
+// Use Client.ListSitesIter to get an iterator.
+//
+// The iteration stops when there are no more sites or when a request fails. Check Err after the iteration to know
+// whether it stopped because of an error:
+//
+//	it := client.ListSitesIter()
+//	for it.Next() {
+//	    site := it.Site()
+//	    // use site
+//	}
+//	if err := it.Err(); err != nil {
+//	    // handle error
+//	}
+//
+// A SitesIterator is not safe for concurrent use.
+type SitesIterator struct {
+	ctx        context.Context
+	client     *Client
+	pagOptions []pagination.Option
+
+	sites   []SiteResult
+	index   int
+	started bool
+	meta    pagination.Meta
+	current SiteResult
+	err     error
+}
+
+func newSitesIterator(ctx context.Context, client *Client, pagOptions []pagination.Option) *SitesIterator {
+	return &SitesIterator{ctx: ctx, client: client, pagOptions: pagOptions}
+}
+
+// Next advances the iterator to the next site, fetching the next page when needed.
+// It returns false when there are no more sites or when a request fails.
+func (it *SitesIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.sites) {
+		if it.started && !it.meta.HasNext() {
+			return false
+		}
+		if !it.fetchPage() {
+			return false
+		}
+	}
+
+	it.current = it.sites[it.index]
+	it.index++
+	return true
+}
+
+// Site returns the current site. It must only be called after a call to Next that returned true.
+func (it *SitesIterator) Site() SiteResult {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SitesIterator) Err() error {
+	return it.err
+}
+
+// fetchPage fetches the next page of sites. It returns false if there are no sites to iterate over.
+func (it *SitesIterator) fetchPage() bool {
+	options := it.pagOptions
+	if it.started {
+		options = append(options[:len(options):len(options)], pagination.After(it.meta.After), pagination.Before(""))
+	}
+
+	res, err := it.client.ListSitesContext(it.ctx, options...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.started = true
+	it.sites, it.index, it.meta = res.Sites, 0, res.Meta
+
+	// Following the cursor of an empty page could make the iterator loop forever.
+	return len(res.Sites) > 0
+}
@@ -0,0 +1,143 @@
+package plausible
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+// newSitesPagesServer returns a server that paginates the given domains with cursors.
+// Requests after the domain failAfter fail.
+func newSitesPagesServer(domains []string, failAfter string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 100
+		}
+		*requests = append(*requests, after)
+
+		if failAfter != "" && after == failAfter {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		start := 0
+		for i, d := range domains {
+			if d == after {
+				start = i + 1
+			}
+		}
+
+		res := ListSitesResult{Meta: pagination.Meta{Limit: limit}}
+		for i := start; i < start+limit && i < len(domains); i++ {
+			res.Sites = append(res.Sites, SiteResult{Domain: domains[i], Timezone: "Etc/UTC"})
+		}
+		if start+limit < len(domains) {
+			res.Meta.After = res.Sites[len(res.Sites)-1].Domain
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestUnitListAllSites(t *testing.T) {
+	domains := []string{"a.com", "b.com", "c.com", "d.com", "e.com"}
+
+	tests := []struct {
+		name             string
+		pagOptions       []pagination.Option
+		expectedDomains  string
+		expectedRequests string
+	}{
+		{
+			name:             "sites in one page",
+			expectedDomains:  "a.com,b.com,c.com,d.com,e.com",
+			expectedRequests: "",
+		},
+		{
+			name:             "sites in pages of 2",
+			pagOptions:       []pagination.Option{pagination.Limit(2)},
+			expectedDomains:  "a.com,b.com,c.com,d.com,e.com",
+			expectedRequests: ",b.com,d.com",
+		},
+		{
+			name:             "sites after a cursor",
+			pagOptions:       []pagination.Option{pagination.Limit(2), pagination.After("a.com")},
+			expectedDomains:  "b.com,c.com,d.com,e.com",
+			expectedRequests: "a.com,c.com",
+		},
+	}
+
+	for _, test := range tests {
+		var requests []string
+		server := newSitesPagesServer(domains, "", &requests)
+
+		sites, err := NewClientWithBaseURL("token", server.URL).ListAllSites(test.pagOptions...)
+		server.Close()
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		var got []string
+		for _, s := range sites {
+			got = append(got, s.Domain)
+		}
+
+		if strings.Join(got, ",") != test.expectedDomains {
+			t.Fatalf("test '%s' failed: expected sites %s, got %s", test.name, test.expectedDomains, strings.Join(got, ","))
+		}
+
+		if strings.Join(requests, ",") != test.expectedRequests {
+			t.Fatalf("test '%s' failed: expected requests after %s, got %s",
+				test.name, test.expectedRequests, strings.Join(requests, ","))
+		}
+	}
+}
+
+func TestUnitListSitesIterError(t *testing.T) {
+	var requests []string
+	server := newSitesPagesServer([]string{"a.com", "b.com", "c.com"}, "b.com", &requests)
+	defer server.Close()
+
+	it := NewClientWithBaseURL("token", server.URL).ListSitesIter(pagination.Limit(2))
+
+	sites := 0
+	for it.Next() {
+		sites++
+	}
+
+	if sites != 2 {
+		t.Fatalf("expected the sites of the first page before the error, got %d sites", sites)
+	}
+
+	if !errors.Is(it.Err(), ErrServerError) {
+		t.Fatalf("expected a server error, got %v", it.Err())
+	}
+}
+
+func TestUnitPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name                string
+		meta                pagination.Meta
+		expectedHasNext     bool
+		expectedHasPrevious bool
+	}{
+		{name: "first page", meta: pagination.Meta{After: "b.com"}, expectedHasNext: true},
+		{name: "middle page", meta: pagination.Meta{After: "d.com", Before: "c.com"}, expectedHasNext: true, expectedHasPrevious: true},
+		{name: "last page", meta: pagination.Meta{Before: "e.com"}, expectedHasPrevious: true},
+		{name: "only page", meta: pagination.Meta{}},
+	}
+
+	for _, test := range tests {
+		if test.meta.HasNext() != test.expectedHasNext || test.meta.HasPrevious() != test.expectedHasPrevious {
+			t.Fatalf("test '%s' failed: expected HasNext %v and HasPrevious %v, got %v and %v", test.name,
+				test.expectedHasNext, test.expectedHasPrevious, test.meta.HasNext(), test.meta.HasPrevious())
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package plausible
+
+import "github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+
+// TeamResult contains the details of a team.
+type TeamResult struct {
+	// ID of the team, used to create and list the sites of the team.
+	ID string `json:"id"`
+	// Name of the team.
+	Name string `json:"name"`
+	// APIAvailable tells whether the team can use the API.
+	APIAvailable bool `json:"api_available"`
+}
+
+// ListTeamsResult is the result of a request to list teams.
+type ListTeamsResult struct {
+	// Teams is the list of teams in a response
+	Teams []TeamResult `json:"teams"`
+	// Meta is the pagination meta information of a page
+	Meta pagination.Meta `json:"meta"`
+}
@@ -0,0 +1,73 @@
+package plausible
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andrerfcsantos/go-plausible/plausible/urlmaker/pagination"
+)
+
+func TestUnitTeamRequests(t *testing.T) {
+	var path, rawQuery string
+	var siteQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, rawQuery = r.URL.Path, r.URL.RawQuery
+
+		if r.URL.Path == "/sites/teams" {
+			_, _ = w.Write([]byte(`{"teams":[{"id":"team-1","name":"Marketing","api_available":true}],` +
+				`"meta":{"limit":100}}`))
+			return
+		}
+
+		siteQueries = append(siteQueries, rawQuery)
+		if r.URL.Query().Get("after") == "" {
+			_, _ = w.Write([]byte(`{"sites":[{"domain":"example.com","timezone":"Etc/UTC"}],` +
+				`"meta":{"after":"example.com","limit":10}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sites":[{"domain":"example.org","timezone":"Etc/UTC"}],"meta":{"limit":10}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL("token", server.URL)
+
+	teams, err := client.ListTeams()
+	if err != nil {
+		t.Fatalf("unexpected error listing the teams: %v", err)
+	}
+	if path != "/sites/teams" {
+		t.Fatalf("expected a request to /sites/teams, got %s", path)
+	}
+	if len(teams.Teams) != 1 || teams.Teams[0].ID != "team-1" || !teams.Teams[0].APIAvailable {
+		t.Fatalf("unexpected list of teams %+v", teams)
+	}
+
+	sites, err := client.ListSites(pagination.Limit(10), pagination.Team("team-1"))
+	if err != nil {
+		t.Fatalf("unexpected error listing the sites of the team: %v", err)
+	}
+	if path != "/sites" || rawQuery != "limit=10&team_id=team-1" {
+		t.Fatalf("expected a request to /sites?limit=10&team_id=team-1, got %s?%s", path, rawQuery)
+	}
+	if len(sites.Sites) != 1 || sites.Sites[0].Domain != "example.com" {
+		t.Fatalf("unexpected list of sites %+v", sites)
+	}
+
+	if _, err = client.ListSites(); err != nil || rawQuery != "" {
+		t.Fatalf("expected a request to list sites without team, got query '%s' and error %v", rawQuery, err)
+	}
+
+	siteQueries = nil
+	allSites, err := client.ListAllSites(pagination.Team("team-1"))
+	if err != nil {
+		t.Fatalf("unexpected error listing all the sites of the team: %v", err)
+	}
+	if len(allSites) != 2 || allSites[0].Domain != "example.com" || allSites[1].Domain != "example.org" {
+		t.Fatalf("unexpected list of all the sites of the team %+v", allSites)
+	}
+	if strings.Join(siteQueries, " ") != "team_id=team-1 after=example.com&team_id=team-1" {
+		t.Fatalf("expected every page of sites to be requested for the team, got queries %v", siteQueries)
+	}
+}
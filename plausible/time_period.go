@@ -1,6 +1,10 @@
 package plausible
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // TimeInterval represents an interval of time by which each entry in the query results must be separated by.
 type TimeInterval string
@@ -12,6 +16,19 @@ const (
 	// MonthInterval represents a time interval of a particular month.
 	// With this time interval, each data point in the result will refer to a particular month.
 	MonthInterval = TimeInterval("month")
+	// MinuteInterval represents a time interval of a minute.
+	// With this time interval, each data point in the result will refer to a particular minute.
+	// This interval can only be used with the realtime and the day periods.
+	MinuteInterval = TimeInterval("minute")
+	// HourInterval represents a time interval of an hour.
+	// With this time interval, each data point in the result will refer to a particular hour.
+	// This interval can only be used with the day, the last 7 days and custom periods.
+	HourInterval = TimeInterval("hour")
+	// WeekInterval represents a time interval of a week.
+	// With this time interval, each data point in the result will refer to a particular week,
+	// identified by its first day.
+	// This interval can't be used with periods shorter than 28 days.
+	WeekInterval = TimeInterval("week")
 )
 
 func (t *TimeInterval) toQueryArgs() QueryArgs {
@@ -32,7 +49,8 @@ func (t *TimeInterval) IsEmpty() bool {
 // package that returns a time period to build a time period,
 // instead of using this struct directly.
 type TimePeriod struct {
-	// Period is a string representing a period of time, e.g "6mo", "12mo", "7d", "30d", "custom", "month", "day" or "custom".
+	// Period is a string representing a period of time, e.g "30m", "day", "7d", "28d", "30d", "91d", "month", "6mo",
+	// "12mo", "year", "all" or "custom".
 	// This field is mandatory.
 	Period string
 	// Date is a string representing a date to which the time period refers to, in the format of "yyyy-mm-dd"
@@ -90,6 +108,55 @@ func DayPeriod() TimePeriod {
 	return TimePeriod{Period: "day"}
 }
 
+// Last28Days returns a time period referring to the last 28 days.
+// To change the date from which the "last 28 days" refer to,
+// chain the return of this function with OfDate or FromDate to add
+// date information to the time period.
+func Last28Days() TimePeriod {
+	return TimePeriod{Period: "28d"}
+}
+
+// Last91Days returns a time period referring to the last 91 days.
+// To change the date from which the "last 91 days" refer to,
+// chain the return of this function with OfDate or FromDate to add
+// date information to the time period.
+func Last91Days() TimePeriod {
+	return TimePeriod{Period: "91d"}
+}
+
+// YearPeriod returns a time period referring to a calendar year.
+// If no additional date information is given, this defaults to mean "the current year", i.e. year to date.
+// To change the year to which this date refers to,
+// chain the return of this function with OfDate or FromDate to add
+// date information to the time period.
+func YearPeriod() TimePeriod {
+	return TimePeriod{Period: "year"}
+}
+
+// AllTimePeriod returns a time period referring to all the time since the site started recording stats.
+func AllTimePeriod() TimePeriod {
+	return TimePeriod{Period: "all"}
+}
+
+// RealtimePeriod returns a time period referring to the last 30 minutes.
+// This period is relative to the current time, so it doesn't accept date information.
+func RealtimePeriod() TimePeriod {
+	return TimePeriod{Period: "30m"}
+}
+
+// QuarterPeriod returns a time period referring to a quarter (1-4) of a year.
+// The API has no quarter period, so the quarter is sent as a custom period between its first and last days.
+// An error is returned if the quarter is not between 1 and 4.
+func QuarterPeriod(year int, quarter int) (TimePeriod, error) {
+	if quarter < 1 || quarter > 4 {
+		return TimePeriod{}, fmt.Errorf("invalid quarter %d, expected a quarter between 1 and 4", quarter)
+	}
+
+	first := time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 3, -1)
+	return CustomPeriod(DateFromTime(first), DateFromTime(last)), nil
+}
+
 // CustomPeriod allows to build a custom time period/range between the two given dates.
 func CustomPeriod(fromDate Date, toDate Date) TimePeriod {
 	return TimePeriod{
@@ -114,6 +181,72 @@ func (tp TimePeriod) FromDate(date Date) TimePeriod {
 	return tp
 }
 
+// periodsByName contains the time periods that ParsePeriod accepts by name.
+var periodsByName = map[string]TimePeriod{
+	"30m":      RealtimePeriod(),
+	"realtime": RealtimePeriod(),
+	"day":      DayPeriod(),
+	"7d":       Last7Days(),
+	"28d":      Last28Days(),
+	"30d":      Last30Days(),
+	"91d":      Last91Days(),
+	"month":    MonthPeriod(),
+	"6mo":      Last6Months(),
+	"12mo":     Last12Months(),
+	"year":     YearPeriod(),
+	"all":      AllTimePeriod(),
+}
+
+// ParsePeriod parses a time period, e.g from a command line flag.
+//
+// The period can be the name of one of the periods supported by the API: "30m" (or "realtime"), "day", "7d",
+// "28d", "30d", "91d", "month", "6mo", "12mo", "year" or "all". Except for "30m" and "all", the name can be
+// followed by "@" and a date in the format "yyyy-mm-dd" to make the period relative to that date,
+// e.g "month@2021-02-01". A custom period is given by two dates separated by a comma, e.g "2021-01-01,2021-01-31".
+func ParsePeriod(period string) (TimePeriod, error) {
+	if from, to, isRange := cut(period, ","); isRange {
+		fromDate, err := ParseDate(from)
+		if err != nil {
+			return TimePeriod{}, fmt.Errorf("invalid start date of custom period '%s': %w", period, err)
+		}
+		toDate, err := ParseDate(to)
+		if err != nil {
+			return TimePeriod{}, fmt.Errorf("invalid end date of custom period '%s': %w", period, err)
+		}
+		if toDate.Before(fromDate) {
+			return TimePeriod{}, fmt.Errorf("invalid custom period '%s': the end date is before the start date", period)
+		}
+		return TimePeriod{Period: "custom", Date: from + "," + to}, nil
+	}
+
+	name, date, hasDate := cut(period, "@")
+	tp, known := periodsByName[name]
+	if !known {
+		return TimePeriod{}, fmt.Errorf("unknown period '%s', expected one of 30m, realtime, day, 7d, 28d, 30d, 91d, "+
+			"month, 6mo, 12mo, year, all or a custom period like 2021-01-01,2021-01-31", name)
+	}
+
+	if hasDate {
+		if tp.Period == "30m" || tp.Period == "all" {
+			return TimePeriod{}, fmt.Errorf("the period '%s' can't be relative to a date", name)
+		}
+		if _, err := ParseDate(date); err != nil {
+			return TimePeriod{}, fmt.Errorf("invalid date of period '%s': %w", period, err)
+		}
+		tp.Date = date
+	}
+
+	return tp, nil
+}
+
+// cut slices s around the first instance of sep, like strings.Cut.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
 func (tp *TimePeriod) toQueryArgs() QueryArgs {
 	qargs := QueryArgs{
 		QueryArg{Name: "period", Value: tp.Period},
@@ -0,0 +1,118 @@
+package plausible
+
+import "testing"
+
+func TestUnitTimePeriods(t *testing.T) {
+	tests := []struct {
+		name           string
+		period         TimePeriod
+		expectedPeriod TimePeriod
+	}{
+		{name: "last 28 days", period: Last28Days(), expectedPeriod: TimePeriod{Period: "28d"}},
+		{name: "last 91 days", period: Last91Days(), expectedPeriod: TimePeriod{Period: "91d"}},
+		{name: "all time", period: AllTimePeriod(), expectedPeriod: TimePeriod{Period: "all"}},
+		{name: "realtime", period: RealtimePeriod(), expectedPeriod: TimePeriod{Period: "30m"}},
+		{
+			name:           "year of a date",
+			period:         YearPeriod().OfDate(Date{Day: 10, Month: 5, Year: 2021}),
+			expectedPeriod: TimePeriod{Period: "year", Date: "2021-05-10"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.period != test.expectedPeriod {
+			t.Fatalf("test '%s' failed: expected period %#v, got %#v", test.name, test.expectedPeriod, test.period)
+		}
+	}
+}
+
+func TestUnitQuarterPeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		year           int
+		quarter        int
+		expectedPeriod TimePeriod
+		shouldFail     bool
+	}{
+		{
+			name:           "first quarter",
+			year:           2021,
+			quarter:        1,
+			expectedPeriod: TimePeriod{Period: "custom", Date: "2021-01-01,2021-03-31"},
+		},
+		{
+			name:           "last quarter",
+			year:           2020,
+			quarter:        4,
+			expectedPeriod: TimePeriod{Period: "custom", Date: "2020-10-01,2020-12-31"},
+		},
+		{name: "quarter 0", year: 2021, quarter: 0, shouldFail: true},
+		{name: "quarter 5", year: 2021, quarter: 5, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		period, err := QuarterPeriod(test.year, test.quarter)
+		if test.shouldFail {
+			if err == nil {
+				t.Fatalf("test '%s' failed: expected an error, got period %#v", test.name, period)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if period != test.expectedPeriod {
+			t.Fatalf("test '%s' failed: expected period %#v, got %#v", test.name, test.expectedPeriod, period)
+		}
+	}
+}
+
+func TestUnitParsePeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		period         string
+		expectedPeriod TimePeriod
+		shouldFail     bool
+	}{
+		{name: "day", period: "day", expectedPeriod: DayPeriod()},
+		{name: "realtime alias", period: "realtime", expectedPeriod: RealtimePeriod()},
+		{name: "last 91 days", period: "91d", expectedPeriod: Last91Days()},
+		{name: "all time", period: "all", expectedPeriod: AllTimePeriod()},
+		{
+			name:           "month relative to a date",
+			period:         "month@2021-02-01",
+			expectedPeriod: MonthPeriod().OfDate(Date{Day: 1, Month: 2, Year: 2021}),
+		},
+		{
+			name:           "custom period",
+			period:         "2021-01-01,2021-01-31",
+			expectedPeriod: CustomPeriod(Date{Day: 1, Month: 1, Year: 2021}, Date{Day: 31, Month: 1, Year: 2021}),
+		},
+		{name: "unknown period", period: "3w", shouldFail: true},
+		{name: "empty period", period: "", shouldFail: true},
+		{name: "realtime relative to a date", period: "30m@2021-02-01", shouldFail: true},
+		{name: "invalid date", period: "day@2021-02-30", shouldFail: true},
+		{name: "custom period with an invalid date", period: "2021-01-01,tomorrow", shouldFail: true},
+		{name: "custom period ending before it starts", period: "2021-02-01,2021-01-01", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParsePeriod(test.period)
+		if test.shouldFail {
+			if err == nil {
+				t.Fatalf("test '%s' failed: expected an error, got period %#v", test.name, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if got != test.expectedPeriod {
+			t.Fatalf("test '%s' failed: expected period %#v, got %#v", test.name, test.expectedPeriod, got)
+		}
+	}
+}
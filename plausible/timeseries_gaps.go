@@ -0,0 +1,163 @@
+package plausible
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FillGaps returns a dense copy of the time series, with a data point for each bucket of time of the period and
+// interval of the query that returned the time series. The API leaves out some buckets without data, so the buckets
+// missing from the time series are added with all metrics at zero and with the Filled field set to true.
+//
+// The range of the period is computed like the API does, e.g the last 7 days are the date of the period and the
+// 6 days before it. Periods without a date are relative to the current date in the timezone of the site, or in UTC
+// if the timezone is not known. Except for custom periods, days after the current date are not filled, e.g the
+// current year is only filled up to today. The "all" and realtime periods have no fixed range and can't be filled.
+func (tr TimeseriesResult) FillGaps(period TimePeriod, interval TimeInterval) (TimeseriesResult, error) {
+	return tr.fillGaps(period, interval, time.Now)
+}
+
+// fillGaps implements FillGaps, getting the current time from the given function.
+func (tr TimeseriesResult) fillGaps(period TimePeriod, interval TimeInterval, now func() time.Time) (TimeseriesResult, error) {
+	location := time.UTC
+	for _, dp := range tr {
+		if dp.location != nil {
+			location = dp.location
+			break
+		}
+	}
+
+	start, end, err := period.dateRange(DateFromTime(now().In(location)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the API, daily series over a day period are returned by hour.
+	if interval.IsEmpty() {
+		interval = DateInterval
+	}
+	if interval == DateInterval && period.Period == "day" {
+		interval = HourInterval
+	}
+
+	buckets, err := timeBuckets(start, end, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]TimeseriesDataPoint, len(tr))
+	for _, dp := range tr {
+		byDate[dp.Date] = dp
+	}
+
+	res := make(TimeseriesResult, 0, len(buckets)+len(tr))
+	for _, bucket := range buckets {
+		dp, found := byDate[bucket]
+		if found {
+			delete(byDate, bucket)
+		} else {
+			dp = TimeseriesDataPoint{Date: bucket, Filled: true, interval: interval, location: location}
+		}
+		res = append(res, dp)
+	}
+
+	// Data points the buckets don't account for, e.g from periods computed differently by the API, are kept.
+	if len(byDate) > 0 {
+		for _, dp := range tr {
+			if _, extra := byDate[dp.Date]; extra {
+				res = append(res, dp)
+			}
+		}
+		sort.SliceStable(res, func(i, j int) bool { return res[i].Date < res[j].Date })
+	}
+
+	return res, nil
+}
+
+// dateRange returns the first and the last days of the period. Periods without a date are relative to today,
+// and the range of all periods but custom ones ends today at the latest.
+func (tp TimePeriod) dateRange(today Date) (start Date, end Date, err error) {
+	if tp.Period == "custom" {
+		from, to, _ := cut(tp.Date, ",")
+		if start, err = ParseDate(from); err != nil {
+			return Date{}, Date{}, fmt.Errorf("invalid custom period: %w", err)
+		}
+		if end, err = ParseDate(to); err != nil {
+			return Date{}, Date{}, fmt.Errorf("invalid custom period: %w", err)
+		}
+		return start, end, nil
+	}
+
+	date := today
+	if tp.Date != "" {
+		if date, err = ParseDate(tp.Date); err != nil {
+			return Date{}, Date{}, fmt.Errorf("invalid date of period '%s': %w", tp.Period, err)
+		}
+	}
+
+	firstOfMonth := Date{Day: 1, Month: date.Month, Year: date.Year}
+	lastOfMonth := firstOfMonth.AddMonths(1).AddDays(-1)
+	switch tp.Period {
+	case "day":
+		start, end = date, date
+	case "month":
+		start, end = firstOfMonth, lastOfMonth
+	case "6mo":
+		start, end = firstOfMonth.AddMonths(-5), lastOfMonth
+	case "12mo":
+		start, end = firstOfMonth.AddMonths(-11), lastOfMonth
+	case "year":
+		start, end = Date{Day: 1, Month: 1, Year: date.Year}, Date{Day: 31, Month: 12, Year: date.Year}
+	default:
+		days, err := strconv.Atoi(strings.TrimSuffix(tp.Period, "d"))
+		if !strings.HasSuffix(tp.Period, "d") || err != nil || days <= 0 {
+			return Date{}, Date{}, fmt.Errorf("the period '%s' has no fixed range of dates", tp.Period)
+		}
+		start, end = date.AddDays(-(days - 1)), date
+	}
+
+	if end.After(today) {
+		end = today
+	}
+
+	return start, end, nil
+}
+
+// timeBuckets returns the dates, in the format used by the API, of the buckets of an interval between two days.
+func timeBuckets(start Date, end Date, interval TimeInterval) ([]string, error) {
+	var buckets []string
+
+	switch interval {
+	case MinuteInterval, HourInterval:
+		step := time.Hour
+		if interval == MinuteInterval {
+			step = time.Minute
+		}
+		last := end.AddDays(1).ToTime(time.UTC)
+		for t := start.ToTime(time.UTC); t.Before(last); t = t.Add(step) {
+			buckets = append(buckets, DateTimeFromTime(t).toPlausibleFormat())
+		}
+	case DateInterval:
+		for d := start; !d.After(end); d = d.AddDays(1) {
+			buckets = append(buckets, d.toPlausibleFormat())
+		}
+	case WeekInterval:
+		// The first bucket starts on the first day of the range, the others on Mondays.
+		for d := start; !d.After(end); {
+			buckets = append(buckets, d.toPlausibleFormat())
+			weekday := int(d.ToTime(time.UTC).Weekday())
+			d = d.AddDays(7 - (weekday+6)%7)
+		}
+	case MonthInterval:
+		for d := (Date{Day: 1, Month: start.Month, Year: start.Year}); !d.After(end); d = d.AddMonths(1) {
+			buckets = append(buckets, d.toPlausibleFormat())
+		}
+	default:
+		return nil, fmt.Errorf("unknown interval '%s'", interval)
+	}
+
+	return buckets, nil
+}
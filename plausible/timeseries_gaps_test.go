@@ -0,0 +1,193 @@
+package plausible
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnitFillGaps(t *testing.T) {
+	tests := []struct {
+		name           string
+		series         TimeseriesResult
+		period         TimePeriod
+		interval       TimeInterval
+		expectedDates  string
+		expectedFilled string
+	}{
+		{
+			name: "daily series over the last 7 days",
+			series: TimeseriesResult{
+				{Date: "2021-02-26", MetricsResult: MetricsResult{Visitors: 3}},
+				{Date: "2021-03-01", MetricsResult: MetricsResult{Visitors: 0}},
+			},
+			period:         Last7Days().OfDate(Date{Day: 2, Month: 3, Year: 2021}),
+			interval:       DateInterval,
+			expectedDates:  "2021-02-24,2021-02-25,2021-02-26,2021-02-27,2021-02-28,2021-03-01,2021-03-02",
+			expectedFilled: "yy-yy-y",
+		},
+		{
+			name:           "empty daily series over a custom period",
+			period:         CustomPeriod(Date{Day: 30, Month: 12, Year: 2020}, Date{Day: 1, Month: 1, Year: 2021}),
+			expectedDates:  "2020-12-30,2020-12-31,2021-01-01",
+			expectedFilled: "yyy",
+		},
+		{
+			name: "monthly series over the last 6 months",
+			series: TimeseriesResult{
+				{Date: "2021-04-01", MetricsResult: MetricsResult{Visitors: 3}},
+			},
+			period:         Last6Months().OfDate(Date{Day: 15, Month: 5, Year: 2021}),
+			interval:       MonthInterval,
+			expectedDates:  "2020-12-01,2021-01-01,2021-02-01,2021-03-01,2021-04-01,2021-05-01",
+			expectedFilled: "yyyy-y",
+		},
+		{
+			name: "weekly series over a month",
+			series: TimeseriesResult{
+				{Date: "2021-02-01", MetricsResult: MetricsResult{Visitors: 3}},
+			},
+			period:         MonthPeriod().OfDate(Date{Day: 1, Month: 2, Year: 2021}),
+			interval:       WeekInterval,
+			expectedDates:  "2021-02-01,2021-02-08,2021-02-15,2021-02-22",
+			expectedFilled: "-yyy",
+		},
+		{
+			name: "weekly series starting in the middle of a week",
+			period: CustomPeriod(Date{Day: 3, Month: 2, Year: 2021},
+				Date{Day: 16, Month: 2, Year: 2021}),
+			interval:       WeekInterval,
+			expectedDates:  "2021-02-03,2021-02-08,2021-02-15",
+			expectedFilled: "yyy",
+		},
+		{
+			name: "hourly series of a day with the date interval",
+			series: TimeseriesResult{
+				{Date: "2021-02-01 05:00:00", MetricsResult: MetricsResult{Visitors: 3}},
+			},
+			period:         DayPeriod().OfDate(Date{Day: 1, Month: 2, Year: 2021}),
+			interval:       DateInterval,
+			expectedDates:  "2021-02-01 00:00:00,2021-02-01 01:00:00,2021-02-01 02:00:00",
+			expectedFilled: "yyyyy-yyyyyyyyyyyyyyyyyy",
+		},
+		{
+			name: "series with a data point outside of the period",
+			series: TimeseriesResult{
+				{Date: "2021-01-31", MetricsResult: MetricsResult{Visitors: 3}},
+			},
+			period:         CustomPeriod(Date{Day: 1, Month: 2, Year: 2021}, Date{Day: 2, Month: 2, Year: 2021}),
+			interval:       DateInterval,
+			expectedDates:  "2021-01-31,2021-02-01,2021-02-02",
+			expectedFilled: "-yy",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.series.FillGaps(test.period, test.interval)
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		var dates []string
+		filled := ""
+		for _, dp := range got {
+			dates = append(dates, dp.Date)
+			if dp.Filled {
+				filled += "y"
+				if dp.Visitors != 0 {
+					t.Fatalf("test '%s' failed: filled data point %s has %d visitors", test.name, dp.Date, dp.Visitors)
+				}
+			} else {
+				filled += "-"
+			}
+		}
+
+		if !strings.HasPrefix(strings.Join(dates, ","), test.expectedDates) {
+			t.Fatalf("test '%s' failed: expected dates %s, got %s", test.name, test.expectedDates, strings.Join(dates, ","))
+		}
+
+		if filled != test.expectedFilled {
+			t.Fatalf("test '%s' failed: expected filled data points %s, got %s", test.name, test.expectedFilled, filled)
+		}
+	}
+}
+
+func TestUnitFillGapsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   TimePeriod
+		interval TimeInterval
+	}{
+		{name: "all time period", period: AllTimePeriod(), interval: MonthInterval},
+		{name: "realtime period", period: RealtimePeriod(), interval: MinuteInterval},
+		{name: "unknown interval", period: Last7Days(), interval: TimeInterval("fortnight")},
+		{name: "invalid date", period: TimePeriod{Period: "day", Date: "2021-02-30"}, interval: HourInterval},
+	}
+
+	for _, test := range tests {
+		if _, err := (TimeseriesResult{}).FillGaps(test.period, test.interval); err == nil {
+			t.Fatalf("test '%s' failed: expected an error", test.name)
+		}
+	}
+}
+
+func TestUnitFillGapsUntilToday(t *testing.T) {
+	now := func() time.Time {
+		return time.Date(2021, 3, 3, 12, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		period        TimePeriod
+		interval      TimeInterval
+		expectedDates string
+	}{
+		{
+			name:          "current month by day",
+			period:        MonthPeriod(),
+			interval:      DateInterval,
+			expectedDates: "2021-03-01,2021-03-02,2021-03-03",
+		},
+		{
+			name:          "current year by month",
+			period:        YearPeriod(),
+			interval:      MonthInterval,
+			expectedDates: "2021-01-01,2021-02-01,2021-03-01",
+		},
+		{
+			name:     "current year by week",
+			period:   YearPeriod(),
+			interval: WeekInterval,
+			expectedDates: "2021-01-01,2021-01-04,2021-01-11,2021-01-18,2021-01-25," +
+				"2021-02-01,2021-02-08,2021-02-15,2021-02-22,2021-03-01",
+		},
+		{
+			name:          "past month",
+			period:        MonthPeriod().OfDate(Date{Day: 1, Month: 2, Year: 2021}),
+			interval:      WeekInterval,
+			expectedDates: "2021-02-01,2021-02-08,2021-02-15,2021-02-22",
+		},
+		{
+			name:          "custom period ending after today",
+			period:        CustomPeriod(Date{Day: 2, Month: 3, Year: 2021}, Date{Day: 5, Month: 3, Year: 2021}),
+			interval:      DateInterval,
+			expectedDates: "2021-03-02,2021-03-03,2021-03-04,2021-03-05",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := (TimeseriesResult{}).fillGaps(test.period, test.interval, now)
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		var dates []string
+		for _, dp := range got {
+			dates = append(dates, dp.Date)
+		}
+
+		if strings.Join(dates, ",") != test.expectedDates {
+			t.Fatalf("test '%s' failed: expected dates %s, got %s", test.name, test.expectedDates, strings.Join(dates, ","))
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package plausible
 
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
 // TimeseriesQuery represents an API query for time series information over a period of time.
 // In an aggregate query, the Metrics field is mandatory, all the others are optional.
 type TimeseriesQuery struct {
@@ -20,13 +26,29 @@ type TimeseriesQuery struct {
 
 // Validate tells whether the query is valid or not.
 // If the query is not valid, a string explaining why the query is not valid will be returned.
+// Besides the mandatory fields, the compatibility of the metrics with the filters and the other fields
+// of the query is checked, and all the problems found are included in the string.
 func (aq *TimeseriesQuery) Validate() (ok bool, invalidReason string) {
+	return validationResult(aq.validationProblems())
+}
+
+// Check validates the query like Validate, but returns a *ValidationError with all the problems found in the query,
+// or nil if the query is valid.
+func (aq *TimeseriesQuery) Check() error {
+	return validationError("timeline", aq.validationProblems())
+}
+
+func (aq *TimeseriesQuery) validationProblems() []ValidationProblem {
+	v := queryValidator{}
 
 	if aq.Period.IsEmpty() {
-		return false, "a period must be specified for a timeseries query"
+		v.addProblem("Period", "a period must be specified for a timeseries query")
 	}
+	v.checkInterval(aq.Interval, aq.Period)
+	v.checkFilters(aq.Filters)
+	v.checkMetrics(aq.Metrics, aq.Filters, "")
 
-	return true, ""
+	return v.problems
 }
 
 func (aq *TimeseriesQuery) toQueryArgs() QueryArgs {
@@ -83,6 +105,50 @@ type MetricsResult struct {
 	// Visits contains information about the number of visits per session.
 	// This field must only be used if the query requested the visits metric.
 	Visits int `json:"visits"`
+
+	// Events contains information about the number of events.
+	// This field must only be used if the query requested the events metric.
+	Events int `json:"events"`
+
+	// ViewsPerVisitRaw contains information about the number of page views per visit.
+	// This field must only be used if the query requested the views per visit metric, and it can be nil.
+	// If you don't care about the nil value, use the ViewsPerVisit function to get this value.
+	ViewsPerVisitRaw *float64 `json:"views_per_visit"`
+
+	// ConversionRateRaw contains information about the conversion rate.
+	// This field must only be used if the query requested the conversion rate metric, and it can be nil.
+	// If you don't care about the nil value, use the ConversionRate function to get this value.
+	ConversionRateRaw *float64 `json:"conversion_rate"`
+
+	// GroupConversionRateRaw contains information about the conversion rate of the group of a breakdown.
+	// This field must only be used if the query requested the group conversion rate metric, and it can be nil.
+	// If you don't care about the nil value, use the GroupConversionRate function to get this value.
+	GroupConversionRateRaw *float64 `json:"group_conversion_rate"`
+
+	// TimeOnPageRaw contains information about the time on page, in seconds.
+	// This field must only be used if the query requested the time on page metric, and it can be nil.
+	// If you don't care about the nil value, use the TimeOnPage function to get this value.
+	TimeOnPageRaw *float64 `json:"time_on_page"`
+
+	// PercentageRaw contains information about the percentage of visitors of the group of a breakdown.
+	// This field must only be used if the query requested the percentage metric, and it can be nil.
+	// If you don't care about the nil value, use the Percentage function to get this value.
+	PercentageRaw *float64 `json:"percentage"`
+
+	// ScrollDepthRaw contains information about the scroll depth.
+	// This field must only be used if the query requested the scroll depth metric, and it can be nil.
+	// If you don't care about the nil value, use the ScrollDepth function to get this value.
+	ScrollDepthRaw *float64 `json:"scroll_depth"`
+
+	// TotalRevenueRaw contains information about the total revenue.
+	// This field must only be used if the query requested the total revenue metric, and it can be nil.
+	// If you don't care about the nil value, use the TotalRevenue function to get this value.
+	TotalRevenueRaw *RevenueValue `json:"total_revenue"`
+
+	// AverageRevenueRaw contains information about the average revenue.
+	// This field must only be used if the query requested the average revenue metric, and it can be nil.
+	// If you don't care about the nil value, use the AverageRevenue function to get this value.
+	AverageRevenueRaw *RevenueValue `json:"average_revenue"`
 }
 
 // BounceRate returns the bounce rate associated with this result.
@@ -103,11 +169,139 @@ func (mr *MetricsResult) VisitDuration() float64 {
 	return *mr.VisitDurationRaw
 }
 
+// ViewsPerVisit returns the number of page views per visit associated with this result.
+// It will return 0 (zero) if the views per visit information is not present.
+func (mr *MetricsResult) ViewsPerVisit() float64 {
+	return floatOrZero(mr.ViewsPerVisitRaw)
+}
+
+// ConversionRate returns the conversion rate associated with this result.
+// It will return 0 (zero) if the conversion rate information is not present.
+func (mr *MetricsResult) ConversionRate() float64 {
+	return floatOrZero(mr.ConversionRateRaw)
+}
+
+// GroupConversionRate returns the group conversion rate associated with this result.
+// It will return 0 (zero) if the group conversion rate information is not present.
+func (mr *MetricsResult) GroupConversionRate() float64 {
+	return floatOrZero(mr.GroupConversionRateRaw)
+}
+
+// TimeOnPage returns the time on page associated with this result.
+// It will return 0 (zero) if the time on page information is not present.
+func (mr *MetricsResult) TimeOnPage() float64 {
+	return floatOrZero(mr.TimeOnPageRaw)
+}
+
+// Percentage returns the percentage of visitors associated with this result.
+// It will return 0 (zero) if the percentage information is not present.
+func (mr *MetricsResult) Percentage() float64 {
+	return floatOrZero(mr.PercentageRaw)
+}
+
+// ScrollDepth returns the scroll depth associated with this result.
+// It will return 0 (zero) if the scroll depth information is not present.
+func (mr *MetricsResult) ScrollDepth() float64 {
+	return floatOrZero(mr.ScrollDepthRaw)
+}
+
+// TotalRevenue returns the total revenue associated with this result.
+// It will return an empty RevenueValue if the total revenue information is not present.
+func (mr *MetricsResult) TotalRevenue() RevenueValue {
+	return revenueOrZero(mr.TotalRevenueRaw)
+}
+
+// AverageRevenue returns the average revenue associated with this result.
+// It will return an empty RevenueValue if the average revenue information is not present.
+func (mr *MetricsResult) AverageRevenue() RevenueValue {
+	return revenueOrZero(mr.AverageRevenueRaw)
+}
+
+// RevenueValue represents the value of a revenue metric.
+type RevenueValue struct {
+	// Value is the amount of the revenue.
+	Value float64 `json:"value"`
+	// Currency is the ISO 4217 code of the currency of the revenue, e.g "EUR".
+	Currency string `json:"currency"`
+	// Short is the amount of the revenue formatted in a short form, e.g "€1.2K".
+	Short string `json:"short"`
+	// Long is the amount of the revenue formatted in a long form, e.g "€1,234.50".
+	Long string `json:"long"`
+}
+
+// UnmarshalJSON decodes a revenue value, which the API sends either as an object
+// with the amount and the currency or as a plain number.
+func (rv *RevenueValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		return json.Unmarshal(data, &rv.Value)
+	}
+
+	type rawRevenueValue RevenueValue
+	return json.Unmarshal(data, (*rawRevenueValue)(rv))
+}
+
+func floatOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func revenueOrZero(value *RevenueValue) RevenueValue {
+	if value == nil {
+		return RevenueValue{}
+	}
+	return *value
+}
+
 // TimeseriesDataPoint represents a data point in a time series result.
 type TimeseriesDataPoint struct {
 	// Date is a string containing information about the date this result refers to in the format of "yyyy-mm-dd".
 	// For some queries, this string will also include information about an hour of day, in the format "yyyy-mm-dd hh:mm:ss"
+	// Use the DateTime or the Time methods to parse this string.
 	Date string `json:"date"`
 	// MetricsResult contains the metric results for the metrics included in the query
 	MetricsResult
+	// Filled tells whether the data point was added by TimeseriesResult.FillGaps for a bucket of time
+	// the API returned no data for. All the metrics of filled data points are zero.
+	Filled bool `json:"-"`
+
+	// interval is the interval of the query of the data point.
+	interval TimeInterval
+	// location is the timezone of the site of the data point.
+	location *time.Location
+}
+
+// DateTime parses the Date of the data point, in either the "yyyy-mm-dd" or the "yyyy-mm-dd hh:mm:ss" formats.
+// For dates without time of day, as in data points of daily series, the time of day is midnight.
+func (dp *TimeseriesDataPoint) DateTime() (DateTime, error) {
+	return ParseDateTime(dp.Date)
+}
+
+// Time parses the Date of the data point into the time the bucket of the data point starts, in the timezone of the
+// site. The timezone of the site is only known if the data point was returned by a site handler made with
+// Site.WithTimezone, otherwise UTC is used.
+//
+// Check the Granularity method to know the length of the bucket.
+func (dp *TimeseriesDataPoint) Time() (time.Time, error) {
+	dt, err := dp.DateTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dt.ToTime(dp.location), nil
+}
+
+// Granularity returns the length of the bucket of time the data point refers to, e.g HourInterval for the
+// data points of an hourly series. Data points of daily series over a day period, which the API returns by hour,
+// have the HourInterval granularity.
+func (dp *TimeseriesDataPoint) Granularity() TimeInterval {
+	switch dp.interval {
+	case "", DateInterval:
+		if len(dp.Date) > len(dateLayout) {
+			return HourInterval
+		}
+		return DateInterval
+	}
+	return dp.interval
 }
@@ -1,9 +1,13 @@
 package plausible
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestUnitValidateTimeseriesQuery(t *testing.T) {
@@ -94,6 +98,188 @@ func TestUnitToQueryArgsTimeseriesQuery(t *testing.T) {
 
 }
 
+func TestUnitMetricsResultDecoding(t *testing.T) {
+	tests := []struct {
+		name                   string
+		dataPoint              string
+		expectedEvents         int
+		expectedViewsPerVisit  float64
+		expectedConversionRate float64
+		expectedScrollDepth    float64
+		expectedTotalRevenue   RevenueValue
+		expectNilRevenue       bool
+	}{
+		{
+			name:                   "data point with all the metrics",
+			dataPoint:              `{"date":"2021-01-01","events":12,"views_per_visit":1.5,"conversion_rate":2.3,"scroll_depth":60,"total_revenue":{"value":10.5,"currency":"EUR","short":"€10.5","long":"€10.50"}}`,
+			expectedEvents:         12,
+			expectedViewsPerVisit:  1.5,
+			expectedConversionRate: 2.3,
+			expectedScrollDepth:    60,
+			expectedTotalRevenue:   RevenueValue{Value: 10.5, Currency: "EUR", Short: "€10.5", Long: "€10.50"},
+		},
+		{
+			name:                 "data point with revenue as a number",
+			dataPoint:            `{"date":"2021-01-01","total_revenue":7}`,
+			expectedTotalRevenue: RevenueValue{Value: 7},
+		},
+		{
+			name:             "data point with null metrics",
+			dataPoint:        `{"date":"2021-01-01","views_per_visit":null,"scroll_depth":null,"total_revenue":null}`,
+			expectNilRevenue: true,
+		},
+	}
+
+	for _, test := range tests {
+		var dp TimeseriesDataPoint
+		if err := json.Unmarshal([]byte(test.dataPoint), &dp); err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if dp.Events != test.expectedEvents || dp.ViewsPerVisit() != test.expectedViewsPerVisit ||
+			dp.ConversionRate() != test.expectedConversionRate || dp.ScrollDepth() != test.expectedScrollDepth {
+			t.Fatalf("test '%s' failed: unexpected metrics %+v", test.name, dp.MetricsResult)
+		}
+
+		if dp.TotalRevenue() != test.expectedTotalRevenue {
+			t.Fatalf("test '%s' failed: expected total revenue %+v, got %+v",
+				test.name, test.expectedTotalRevenue, dp.TotalRevenue())
+		}
+
+		if test.expectNilRevenue && dp.TotalRevenueRaw != nil {
+			t.Fatalf("test '%s' failed: expected nil total revenue, got %+v", test.name, *dp.TotalRevenueRaw)
+		}
+	}
+}
+
+func TestUnitTimeseriesDataPointDateTime(t *testing.T) {
+	tests := []struct {
+		name             string
+		date             string
+		expectedDateTime DateTime
+		shouldFail       bool
+	}{
+		{
+			name:             "date of a daily series",
+			date:             "2021-01-31",
+			expectedDateTime: DateTime{Date: Date{Day: 31, Month: 1, Year: 2021}},
+		},
+		{
+			name:             "date and time of an hourly series",
+			date:             "2021-01-31 13:00:00",
+			expectedDateTime: DateTime{Date: Date{Day: 31, Month: 1, Year: 2021}, Time: Time{Hour: 13}},
+		},
+		{
+			name:             "date and time of a minute series",
+			date:             "2021-01-31 13:42:00",
+			expectedDateTime: DateTime{Date: Date{Day: 31, Month: 1, Year: 2021}, Time: Time{Hour: 13, Minute: 42}},
+		},
+		{name: "invalid date", date: "2021-02-30", shouldFail: true},
+		{name: "invalid time", date: "2021-01-31 25:00:00", shouldFail: true},
+		{name: "empty date", date: "", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		dp := TimeseriesDataPoint{Date: test.date}
+		got, err := dp.DateTime()
+		if test.shouldFail {
+			if err == nil {
+				t.Fatalf("test '%s' failed: expected an error, got %v", test.name, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if got != test.expectedDateTime {
+			t.Fatalf("test '%s' failed: expected %v, got %v", test.name, test.expectedDateTime, got)
+		}
+	}
+}
+
+func TestUnitTimeseriesDataPointTime(t *testing.T) {
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com").WithTimezone(lisbon)
+
+	tests := []struct {
+		name                string
+		query               TimeseriesQuery
+		response            string
+		expectedTime        time.Time
+		expectedGranularity TimeInterval
+	}{
+		{
+			name:                "hourly series of a day with the date interval",
+			query:               TimeseriesQuery{Period: DayPeriod(), Interval: DateInterval},
+			response:            `{"results":[{"date":"2021-07-01 13:00:00","visitors":2}]}`,
+			expectedTime:        time.Date(2021, time.July, 1, 13, 0, 0, 0, lisbon),
+			expectedGranularity: HourInterval,
+		},
+		{
+			name:                "hourly series",
+			query:               TimeseriesQuery{Period: Last7Days(), Interval: HourInterval},
+			response:            `{"results":[{"date":"2021-07-01 09:00:00","visitors":2}]}`,
+			expectedTime:        time.Date(2021, time.July, 1, 9, 0, 0, 0, lisbon),
+			expectedGranularity: HourInterval,
+		},
+		{
+			name:                "daily series without interval",
+			query:               TimeseriesQuery{Period: Last30Days()},
+			response:            `{"results":[{"date":"2021-07-01","visitors":2}]}`,
+			expectedTime:        time.Date(2021, time.July, 1, 0, 0, 0, 0, lisbon),
+			expectedGranularity: DateInterval,
+		},
+		{
+			name:                "weekly series",
+			query:               TimeseriesQuery{Period: Last91Days(), Interval: WeekInterval},
+			response:            `{"results":[{"date":"2021-06-28","visitors":2}]}`,
+			expectedTime:        time.Date(2021, time.June, 28, 0, 0, 0, 0, lisbon),
+			expectedGranularity: WeekInterval,
+		},
+		{
+			name:                "monthly series",
+			query:               TimeseriesQuery{Period: Last6Months(), Interval: MonthInterval},
+			response:            `{"results":[{"date":"2021-06-01","visitors":2}]}`,
+			expectedTime:        time.Date(2021, time.June, 1, 0, 0, 0, 0, lisbon),
+			expectedGranularity: MonthInterval,
+		},
+	}
+
+	for _, test := range tests {
+		response = test.response
+		res, err := site.Timeseries(test.query)
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		got, err := res[0].Time()
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if !got.Equal(test.expectedTime) || got.Location() != lisbon {
+			t.Fatalf("test '%s' failed: expected time %v, got %v", test.name, test.expectedTime, got)
+		}
+
+		if res[0].Granularity() != test.expectedGranularity {
+			t.Fatalf("test '%s' failed: expected granularity %s, got %s",
+				test.name, test.expectedGranularity, res[0].Granularity())
+		}
+	}
+}
+
 func TestIntegrationTimeseries(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,184 @@
+package plausible
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Transport sends HTTP requests to the Plausible API on behalf of a Client.
+//
+// This package provides two implementations: one built on top of net/http, created with NewNetHTTPTransport,
+// and one built on top of fasthttp, created with NewFastHTTPTransport. By default, clients use a fasthttp transport.
+// Most users won't need to implement this interface and can just pick one of these implementations using
+// the WithHTTPClient, WithFastHTTPClient or WithTransport client options.
+//
+// Implementations must be safe for concurrent use and must return as soon as the context is done.
+type Transport interface {
+	// Do sends the request and returns its response.
+	// An error must only be returned if the request could not be performed. Responses with
+	// non-2xx status codes must be returned as a response and not as an error.
+	Do(ctx context.Context, req *HTTPRequest) (*HTTPResponse, error)
+}
+
+// HTTPRequest represents an HTTP request to be sent by a Transport.
+type HTTPRequest struct {
+	// Method is the HTTP method of the request, e.g "GET".
+	Method string
+	// URL is the complete URL of the request, including the query string.
+	URL string
+	// Header contains the headers of the request.
+	Header http.Header
+	// Body is the body of the request. It's nil for requests without body.
+	Body []byte
+
+	// idempotent tells whether the request can be safely retried without opting in with RetryNonIdempotent.
+	idempotent bool
+	// exemptFromRateLimit tells whether the request is not subject to the client-side rate limiter.
+	exemptFromRateLimit bool
+}
+
+// HTTPResponse represents an HTTP response received by a Transport.
+type HTTPResponse struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header contains the headers of the response.
+	Header http.Header
+	// Body is the body of the response.
+	Body []byte
+}
+
+// NetHTTPTransport is a Transport that uses a net/http client to send requests.
+// Create one with NewNetHTTPTransport.
+type NetHTTPTransport struct {
+	client *http.Client
+}
+
+// NewNetHTTPTransport returns a Transport that sends requests with the given net/http client.
+// This allows the use of proxies, custom TLS configurations and http.RoundTripper middleware.
+// If the given client is nil, a new client with the default settings will be used.
+func NewNetHTTPTransport(client *http.Client) *NetHTTPTransport {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &NetHTTPTransport{client: client}
+}
+
+// Do sends the request using the underlying net/http client.
+func (t *NetHTTPTransport) Do(ctx context.Context, req *HTTPRequest) (*HTTPResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	httpReq.Header = req.Header.Clone()
+
+	httpResp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return &HTTPResponse{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header,
+		Body:       body,
+	}, nil
+}
+
+// FastHTTPTransport is a Transport that uses a fasthttp client to send requests.
+// Create one with NewFastHTTPTransport.
+type FastHTTPTransport struct {
+	client *fasthttp.Client
+}
+
+// NewFastHTTPTransport returns a Transport that sends requests with the given fasthttp client.
+// If the given client is nil, a new client with the default settings will be used.
+func NewFastHTTPTransport(client *fasthttp.Client) *FastHTTPTransport {
+	if client == nil {
+		client = &fasthttp.Client{}
+	}
+
+	return &FastHTTPTransport{client: client}
+}
+
+// Do sends the request using the underlying fasthttp client.
+//
+// fasthttp has no support for contexts, so the request runs in its own goroutine and Do returns
+// as soon as the context is done. The deadline of the context, if any, is also passed to fasthttp.
+func (t *FastHTTPTransport) Do(ctx context.Context, req *HTTPRequest) (*HTTPResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fastReq := fasthttp.AcquireRequest()
+	fastResp := fasthttp.AcquireResponse()
+	release := func() {
+		fasthttp.ReleaseRequest(fastReq)
+		fasthttp.ReleaseResponse(fastResp)
+	}
+
+	fastReq.SetRequestURI(req.URL)
+	fastReq.Header.SetMethod(req.Method)
+	for name, values := range req.Header {
+		for _, value := range values {
+			fastReq.Header.Add(name, value)
+		}
+	}
+	if req.Body != nil {
+		fastReq.SetBody(req.Body)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if deadline, ok := ctx.Deadline(); ok {
+			done <- t.client.DoDeadline(fastReq, fastResp, deadline)
+			return
+		}
+		done <- t.client.Do(fastReq, fastResp)
+	}()
+
+	select {
+	case <-ctx.Done():
+		// The request and response are still in use by fasthttp, release them only after it's done.
+		go func() {
+			<-done
+			release()
+		}()
+		return nil, ctx.Err()
+	case err := <-done:
+		defer release()
+		if err != nil {
+			// fasthttp may notice the deadline before the context does, report it the same way.
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if _, ok := ctx.Deadline(); ok && errors.Is(err, fasthttp.ErrTimeout) {
+				return nil, context.DeadlineExceeded
+			}
+			return nil, err
+		}
+	}
+
+	header := http.Header{}
+	fastResp.Header.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+
+	return &HTTPResponse{
+		StatusCode: fastResp.StatusCode(),
+		Header:     header,
+		// The body is owned by the response, which goes back to the pool once this function returns.
+		Body: append([]byte(nil), fastResp.Body()...),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package plausible
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type countingRoundTripper struct {
+	count int
+	next  http.RoundTripper
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.count++
+	return rt.next.RoundTrip(req)
+}
+
+func TestUnitTransports(t *testing.T) {
+	var (
+		gotMethod, gotPath, gotAuth, gotSiteID, gotContentType string
+		gotBody                                                []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		gotAuth, gotContentType = r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+		gotSiteID = r.URL.Query().Get("site_id")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"friends","url":"https://plausible.io/share/example.com?auth=a"}`))
+	}))
+	defer server.Close()
+
+	roundTripper := &countingRoundTripper{next: http.DefaultTransport}
+
+	tests := []struct {
+		name   string
+		client *Client
+	}{
+		{
+			name:   "default transport",
+			client: NewClientWithBaseURL("token", server.URL),
+		},
+		{
+			name:   "fasthttp transport",
+			client: NewClientWithTransport("token", NewFastHTTPTransport(nil)),
+		},
+		{
+			name:   "net/http transport with round tripper middleware",
+			client: NewClientWithTransport("token", NewNetHTTPTransport(&http.Client{Transport: roundTripper})),
+		},
+	}
+
+	for _, test := range tests {
+		test.client.baseURL = server.URL + "/api/v1/"
+
+		res, err := test.client.Site("example.com").SharedLink(SharedLinkRequest{Name: "friends"})
+		if err != nil {
+			t.Fatalf("test '%s' failed: unexpected error: %v", test.name, err)
+		}
+
+		if res.Name != "friends" {
+			t.Fatalf("test '%s' failed: expected link name 'friends', got '%s'", test.name, res.Name)
+		}
+
+		if gotMethod != "PUT" || gotPath != "/api/v1/sites/shared-links" || gotSiteID != "example.com" {
+			t.Fatalf("test '%s' failed: unexpected request %s %s?site_id=%s", test.name, gotMethod, gotPath, gotSiteID)
+		}
+
+		if gotAuth != "Bearer token" {
+			t.Fatalf("test '%s' failed: expected authorization header 'Bearer token', got '%s'", test.name, gotAuth)
+		}
+
+		if !strings.HasPrefix(gotContentType, "multipart/form-data") || !strings.Contains(string(gotBody), "friends") {
+			t.Fatalf("test '%s' failed: form data was not sent, got content type '%s' and body '%s'",
+				test.name, gotContentType, gotBody)
+		}
+	}
+
+	if roundTripper.count != 1 {
+		t.Fatalf("expected the round tripper middleware to be called once, got %d calls", roundTripper.count)
+	}
+}
@@ -0,0 +1,50 @@
+package plausible
+
+// UpdateSiteRequest represents a request to change the settings of an existing site in Plausible.
+// Only the fields that are set are changed, but at least one of them must be set.
+type UpdateSiteRequest struct {
+	// Domain is the new domain of the site.
+	// This field is optional and the domain is not changed if it's empty.
+	Domain string
+	// Timezone is the new timezone of the site, with a name according to the IANA database (e.g "Europe/London").
+	// This field is optional and the timezone is not changed if it's empty.
+	Timezone string
+}
+
+func (usr *UpdateSiteRequest) toFormArgs() QueryArgs {
+	res := QueryArgs{}
+
+	if usr.Domain != "" {
+		res = append(res, QueryArg{Name: "domain", Value: usr.Domain})
+	}
+
+	if usr.Timezone != "" {
+		res = append(res, QueryArg{Name: "timezone", Value: usr.Timezone})
+	}
+
+	return res
+}
+
+// Validate tells whether the request is valid or not.
+// If the request is not valid, a string explaining why the request is not valid will be returned.
+func (usr *UpdateSiteRequest) Validate() (bool, string) {
+	if usr.Domain == "" && usr.Timezone == "" {
+		return false, "a domain or a timezone must be specified in a request to update a site"
+	}
+
+	return true, ""
+}
+
+// UpdateSiteResult is the result of a request to update a site.
+type UpdateSiteResult struct {
+	// Domain of the updated site.
+	Domain string `json:"domain"`
+	// Timezone of the updated site.
+	Timezone string `json:"timezone"`
+}
+
+// DeleteSiteResult is the result of a request to delete a site.
+type DeleteSiteResult struct {
+	// Deleted tells whether the site was deleted.
+	Deleted bool `json:"deleted"`
+}
@@ -0,0 +1,127 @@
+package plausible
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnitValidateUpdateSiteRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request UpdateSiteRequest
+		isValid bool
+	}{
+		{
+			name: "valid update site request with domain and timezone that should succeed",
+			request: UpdateSiteRequest{
+				Domain:   "mydomain.com",
+				Timezone: "Europe/Lisbon",
+			},
+			isValid: true,
+		},
+		{
+			name: "valid update site request with only a timezone that should succeed",
+			request: UpdateSiteRequest{
+				Timezone: "Europe/Lisbon",
+			},
+			isValid: true,
+		},
+		{
+			name:    "invalid update site request without domain and timezone that should fail",
+			request: UpdateSiteRequest{},
+			isValid: false,
+		},
+	}
+
+	for _, test := range tests {
+		valid, _ := test.request.Validate()
+		if valid && !test.isValid {
+			t.Fatalf("test '%s' is valid, but was expected to fail", test.name)
+		}
+		if !valid && test.isValid {
+			t.Fatalf("test '%s' is invalid, but was expected to succeed", test.name)
+		}
+	}
+}
+
+func TestUnitToFormArgsUpdateSiteRequest(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          UpdateSiteRequest
+		expectedFormArgs QueryArgs
+	}{
+		{
+			name: "update site request with domain and timezone",
+			request: UpdateSiteRequest{
+				Domain:   "mydomain.com",
+				Timezone: "Europe/Lisbon",
+			},
+			expectedFormArgs: QueryArgs{
+				QueryArg{Name: "domain", Value: "mydomain.com"},
+				QueryArg{Name: "timezone", Value: "Europe/Lisbon"},
+			},
+		},
+		{
+			name: "update site request with only a domain",
+			request: UpdateSiteRequest{
+				Domain: "mydomain.com",
+			},
+			expectedFormArgs: QueryArgs{
+				QueryArg{Name: "domain", Value: "mydomain.com"},
+			},
+		},
+	}
+	for _, test := range tests {
+		actualFormArgs := test.request.toFormArgs()
+		equal := actualFormArgs.equalTo(test.expectedFormArgs)
+		if !equal {
+			t.Fatalf("test '%s' failed: non-equal form args %v and %v",
+				test.name, test.expectedFormArgs, actualFormArgs)
+		}
+	}
+}
+
+func TestUnitUpdateAndDeleteSite(t *testing.T) {
+	var method, path, domain string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		domain = r.FormValue("domain")
+
+		if r.Method == "DELETE" {
+			_, _ = w.Write([]byte(`{"deleted":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"domain":"new.example.com","timezone":"Etc/UTC"}`))
+	}))
+	defer server.Close()
+
+	site := NewClientWithBaseURL("token", server.URL).Site("example.com")
+
+	updated, err := site.Update(UpdateSiteRequest{Domain: "new.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error updating the site: %v", err)
+	}
+	if method != "PUT" || path != "/sites/example.com" || domain != "new.example.com" {
+		t.Fatalf("expected a PUT request to /sites/example.com with the new domain, got %s %s with domain '%s'",
+			method, path, domain)
+	}
+	if updated.Domain != "new.example.com" {
+		t.Fatalf("expected the updated domain new.example.com, got %s", updated.Domain)
+	}
+
+	if _, err = site.Update(UpdateSiteRequest{}); err == nil {
+		t.Fatalf("expected an error updating the site without changes")
+	}
+
+	deleted, err := site.Delete()
+	if err != nil {
+		t.Fatalf("unexpected error deleting the site: %v", err)
+	}
+	if method != "DELETE" || path != "/sites/example.com" {
+		t.Fatalf("expected a DELETE request to /sites/example.com, got %s %s", method, path)
+	}
+	if !deleted.Deleted {
+		t.Fatalf("expected the site to be deleted")
+	}
+}
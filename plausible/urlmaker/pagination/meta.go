@@ -9,3 +9,13 @@ type Meta struct {
 	// Limit limits the number of records in a page
 	Limit int `json:"limit"`
 }
+
+// HasNext tells whether there is a page after the current page, i.e. whether the After cursor is set.
+func (m Meta) HasNext() bool {
+	return m.After != ""
+}
+
+// HasPrevious tells whether there is a page before the current page, i.e. whether the Before cursor is set.
+func (m Meta) HasPrevious() bool {
+	return m.Before != ""
+}
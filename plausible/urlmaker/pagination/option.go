@@ -25,3 +25,11 @@ func Limit(limit int) Option {
 		p.Limit = limit
 	}
 }
+
+// Team sets the team whose sites are listed. It is only used when listing sites,
+// and sites of all the teams are listed when it's not given.
+func Team(teamID string) Option {
+	return func(p *Paginator) {
+		p.TeamID = teamID
+	}
+}
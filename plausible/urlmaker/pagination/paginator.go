@@ -8,6 +8,8 @@ type Paginator struct {
 	Before string
 	// Limit sets the maximum records in the desired page
 	Limit int
+	// TeamID is the ID of the team whose records are desired. It is only used when listing sites.
+	TeamID string
 }
 
 // NewPaginator creates a new paginator with the given options